@@ -36,10 +36,14 @@ func main() {
 	server := api.NewServer(cfg, sqlite, logger)
 
 	httpServer := &http.Server{
-		Addr:         cfg.ListenAddr,
-		Handler:      server.Router(),
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 60 * time.Second,
+		Addr:        cfg.ListenAddr,
+		Handler:     server.Router(),
+		ReadTimeout: 10 * time.Second,
+		// The SSE chat routes can legitimately run well past a minute on a
+		// long reply; a fixed WriteTimeout would truncate them mid-stream.
+		// Those routes bound their own lifetime via
+		// handler.newStreamContext's deadline/idle timeout instead.
+		WriteTimeout: 0,
 		IdleTimeout:  60 * time.Second,
 	}
 
@@ -61,4 +65,3 @@ func main() {
 		logger.Error().Err(err).Msg("graceful shutdown failed")
 	}
 }
-
@@ -1,23 +1,47 @@
 package ai
 
 import (
-	"chat-agent/backend/adapter/internal/models"
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/tmc/langchaingo/prompts"
+
+	"chat-agent/backend/adapter/internal/models"
+	"chat-agent/backend/adapter/internal/ollama"
+	"chat-agent/backend/adapter/internal/tools"
 )
 
-// Agent wraps an AI model for chat interactions.
-// Implement this struct to provide your model wrapper.
+// maxToolHops caps how many round-trips Chat will make with the model
+// while it keeps requesting tool calls, so a misbehaving loop can't run
+// forever.
+const maxToolHops = 5
+
+// toolCallTimeout bounds how long a single tool call may run within a hop,
+// so one slow tool can't stall the whole chat turn.
+const toolCallTimeout = 10 * time.Second
+
+// Agent wraps an Ollama model and a registry of callable tools, driving the
+// multi-turn tool-calling loop for a single chat turn.
 type Agent struct {
 	SystemPrompt string
+	ollama       *ollama.Client
+	tools        tools.Registry
+	logger       zerolog.Logger
 }
 
-// New creates a new AI agent.
-// TODO: Implement this function with your initialization logic.
-func New(systemPrompt string) *Agent {
+// New creates a new AI agent backed by the given Ollama client and tool
+// registry.
+func New(ollamaClient *ollama.Client, toolRegistry tools.Registry, systemPrompt string, logger zerolog.Logger) *Agent {
 	return &Agent{
 		SystemPrompt: systemPrompt,
+		ollama:       ollamaClient,
+		tools:        toolRegistry,
+		logger:       logger,
 	}
 }
 
@@ -37,10 +61,121 @@ type ChatResponse struct {
 	Message Message `json:"message"`
 }
 
-// Chat sends a chat request to the AI model and returns the response.
-// TODO: Implement this method with your model wrapper logic.
-func (a *Agent) Chat(ctx context.Context, req ChatRequest, profile *models.AgentProfile) (*ChatResponse, error) {
+// Chat drives the tool-calling loop: it sends the conversation plus the
+// registered tool schemas to Ollama, and for as long as the model keeps
+// requesting tool calls, dispatches each hop's calls concurrently against
+// the registry and feeds the results back as "tool" messages, until the
+// model returns a plain assistant reply or maxToolHops is reached.
+func (a *Agent) Chat(ctx context.Context, req ChatRequest, profile *models.AgentProfile, conversationHistory string) (*ChatResponse, error) {
+	messages := []ollama.Message{
+		{Role: "system", Content: a.renderSystemPrompt(profile, conversationHistory)},
+	}
+	for _, m := range req.Messages {
+		messages = append(messages, ollama.Message{Role: m.Role, Content: m.Content})
+	}
+
+	specs := a.tools.Specs()
+
+	for hop := 0; hop < maxToolHops; hop++ {
+		resp, err := a.ollama.Chat(ctx, ollama.ChatRequest{
+			Messages: messages,
+			Tools:    specs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("ollama chat failed: %w", err)
+		}
+
+		if len(resp.Message.ToolCalls) == 0 {
+			return &ChatResponse{Message: Message{Role: "assistant", Content: resp.Message.Content}}, nil
+		}
+
+		messages = append(messages, ollama.Message{
+			Role:      resp.Message.Role,
+			Content:   resp.Message.Content,
+			ToolCalls: resp.Message.ToolCalls,
+		})
+		messages = append(messages, a.dispatchHop(ctx, hop, resp.Message.ToolCalls)...)
+	}
+
+	return nil, fmt.Errorf("exceeded max tool-call hops (%d)", maxToolHops)
+}
+
+// dispatchHop runs every tool call in a hop concurrently, each bounded by
+// toolCallTimeout, and returns the resulting "tool" messages in the same
+// order the model requested them.
+func (a *Agent) dispatchHop(ctx context.Context, hop int, calls []ollama.ToolCall) []ollama.Message {
+	results := make([]ollama.Message, len(calls))
+
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call ollama.ToolCall) {
+			defer wg.Done()
+			results[i] = a.callTool(ctx, hop, call)
+		}(i, call)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// callTool executes a single tool call and logs its name, arguments,
+// latency, and result size for observability.
+func (a *Agent) callTool(ctx context.Context, hop int, call ollama.ToolCall) ollama.Message {
+	callCtx, cancel := context.WithTimeout(ctx, toolCallTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result, err := a.dispatchTool(callCtx, call)
+	latency := time.Since(start)
+
+	logEvent := a.logger.Info()
+	if err != nil {
+		logEvent = a.logger.Warn().Err(err)
+		result = json.RawMessage(fmt.Sprintf(`{"error": %q}`, err.Error()))
+	}
+	logEvent.
+		Int("hop", hop).
+		Str("tool", call.Function.Name).
+		RawJSON("args", call.Function.Arguments).
+		Dur("latency", latency).
+		Int("result_bytes", len(result)).
+		Msg("tool call")
+
+	return ollama.Message{
+		Role:       "tool",
+		Content:    string(result),
+		ToolCallID: call.ID,
+	}
+}
+
+func (a *Agent) dispatchTool(ctx context.Context, call ollama.ToolCall) (json.RawMessage, error) {
+	tool, ok := a.tools[call.Function.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool %q", call.Function.Name)
+	}
+	return tool.Call(ctx, call.Function.Arguments)
+}
+
+func (a *Agent) renderSystemPrompt(profile *models.AgentProfile, conversationHistory string) string {
 	prompt := prompts.NewPromptTemplate(a.SystemPrompt,
-		[]string{"AgentName", "Location", "Listings", "ConversationHistory", "Question"})
-	return nil, nil
+		[]string{"AgentName", "Location", "Listings", "ConversationHistory"})
+
+	name, location, listings := "", "", ""
+	if profile != nil {
+		name = profile.Name
+		location = profile.Location
+		listings = strings.Join(profile.Listings, ", ")
+	}
+
+	rendered, err := prompt.Format(map[string]any{
+		"AgentName":           name,
+		"Location":            location,
+		"Listings":            listings,
+		"ConversationHistory": conversationHistory,
+	})
+	if err != nil {
+		return a.SystemPrompt
+	}
+	return rendered
 }
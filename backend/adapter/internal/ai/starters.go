@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"chat-agent/backend/adapter/internal/models"
+	"chat-agent/backend/adapter/internal/ollama"
+)
+
+const starterPromptTemplate = `
+You are helping real estate agent %s in %s come up with opening questions
+their chat assistant can suggest to prospects and homeowners. Their recent
+listings: %s.
+
+Reply with ONLY a JSON array of %d short, specific questions a prospect might
+ask this agent's assistant. No commentary, no markdown fences.
+`
+
+// Starters asks the model for a short list of suggested opening questions
+// tailored to the agent's profile. It does not use the tool-calling loop --
+// this is a single, non-conversational completion.
+func (a *Agent) Starters(ctx context.Context, profile *models.AgentProfile, limit int) ([]string, error) {
+	name, location, listings := "", "", "nothing yet"
+	if profile != nil {
+		name = profile.Name
+		location = profile.Location
+		if len(profile.Listings) > 0 {
+			listings = strings.Join(profile.Listings, ", ")
+		}
+	}
+
+	prompt := fmt.Sprintf(starterPromptTemplate, name, location, listings, limit)
+
+	resp, err := a.ollama.Chat(ctx, ollama.ChatRequest{
+		Messages: []ollama.Message{
+			{Role: "user", Content: prompt},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ollama chat failed: %w", err)
+	}
+
+	starters, err := parseStarterList(resp.Message.Content)
+	if err != nil {
+		return nil, err
+	}
+	if len(starters) > limit {
+		starters = starters[:limit]
+	}
+	return starters, nil
+}
+
+// parseStarterList tolerantly extracts a JSON array of strings from the
+// model's reply, stripping any code fence the model may have added despite
+// being asked not to.
+func parseStarterList(raw string) ([]string, error) {
+	content := strings.TrimSpace(raw)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+	content = strings.TrimSpace(content)
+
+	start := strings.Index(content, "[")
+	end := strings.LastIndex(content, "]")
+	if start == -1 || end == -1 || end < start {
+		return nil, fmt.Errorf("no JSON array found in model response")
+	}
+
+	var starters []string
+	if err := json.Unmarshal([]byte(content[start:end+1]), &starters); err != nil {
+		return nil, fmt.Errorf("failed to parse starter list: %w", err)
+	}
+	return starters, nil
+}
@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"chat-agent/backend/adapter/internal/models"
+	"chat-agent/backend/adapter/internal/ollama"
+)
+
+// StreamChunk is a single event from a streamed chat turn: either a content
+// delta to forward to the client, or a terminal event once the model has
+// produced a final reply with no further tool calls pending.
+type StreamChunk struct {
+	Delta string
+	Done  bool
+	Final *ChatResponse
+	Err   error
+}
+
+// ChatStream drives the same tool-calling loop as Chat, but streams each
+// hop's content token-by-token and only resolves tool calls between hops --
+// so the caller sees the model's final answer as it's generated instead of
+// waiting for the whole response to land at once. Tool-call hops typically
+// carry no content, so nothing is forwarded to the caller until the model
+// commits to a final reply.
+func (a *Agent) ChatStream(ctx context.Context, req ChatRequest, profile *models.AgentProfile, conversationHistory string) (<-chan StreamChunk, error) {
+	messages := []ollama.Message{
+		{Role: "system", Content: a.renderSystemPrompt(profile, conversationHistory)},
+	}
+	for _, m := range req.Messages {
+		messages = append(messages, ollama.Message{Role: m.Role, Content: m.Content})
+	}
+
+	specs := a.tools.Specs()
+
+	hopChunks, err := a.ollama.ChatStream(ctx, ollama.ChatRequest{Messages: messages, Tools: specs})
+	if err != nil {
+		return nil, fmt.Errorf("ollama chat stream failed: %w", err)
+	}
+
+	out := make(chan StreamChunk)
+	go a.runStream(ctx, messages, specs, hopChunks, out)
+	return out, nil
+}
+
+// runStream pumps chunks from the current hop to out, and when a hop ends
+// with tool calls instead of a final answer, dispatches them and opens the
+// next hop's stream, up to maxToolHops.
+func (a *Agent) runStream(ctx context.Context, messages []ollama.Message, specs []ollama.FunctionTool, hopChunks <-chan ollama.ChatStreamChunk, out chan<- StreamChunk) {
+	defer close(out)
+
+	for hop := 0; ; hop++ {
+		var full strings.Builder
+		var last ollama.ChoiceMessage
+
+		for chunk := range hopChunks {
+			last = chunk.Message
+			if chunk.Message.Content == "" {
+				continue
+			}
+			full.WriteString(chunk.Message.Content)
+			select {
+			case out <- StreamChunk{Delta: chunk.Message.Content}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if len(last.ToolCalls) == 0 {
+			out <- StreamChunk{Done: true, Final: &ChatResponse{Message: Message{Role: "assistant", Content: full.String()}}}
+			return
+		}
+
+		if hop >= maxToolHops-1 {
+			out <- StreamChunk{Err: fmt.Errorf("exceeded max tool-call hops (%d)", maxToolHops)}
+			return
+		}
+
+		messages = append(messages, ollama.Message{
+			Role:      last.Role,
+			Content:   last.Content,
+			ToolCalls: last.ToolCalls,
+		})
+		messages = append(messages, a.dispatchHop(ctx, hop, last.ToolCalls)...)
+
+		next, err := a.ollama.ChatStream(ctx, ollama.ChatRequest{Messages: messages, Tools: specs})
+		if err != nil {
+			out <- StreamChunk{Err: fmt.Errorf("ollama chat stream failed: %w", err)}
+			return
+		}
+		hopChunks = next
+	}
+}
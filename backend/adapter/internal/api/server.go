@@ -11,6 +11,11 @@ import (
 	"chat-agent/backend/adapter/internal/config"
 	"chat-agent/backend/adapter/internal/db"
 	"chat-agent/backend/adapter/internal/handler"
+	"chat-agent/backend/adapter/internal/ollama"
+	"chat-agent/backend/adapter/internal/profile"
+	"chat-agent/backend/adapter/internal/search"
+	"chat-agent/backend/adapter/internal/tools"
+	authmw "chat-agent/backend/common/auth"
 )
 
 type Server struct {
@@ -19,20 +24,59 @@ type Server struct {
 
 func NewServer(cfg *config.Config, dbConn *sql.DB, logger zerolog.Logger) *Server {
 	repo := db.NewRepository(dbConn)
-	aiAgent := ai.New()
+	ollamaClient := ollama.New(cfg.OllamaURL, cfg.OllamaModel, cfg.OllamaEmbedModel)
+	searchClient := search.NewClient(cfg.SearchURL)
+	profileClient := profile.NewClient(cfg.ProfileURL, cfg.JWTSecret)
 
-	chatHandler := handler.NewChatHandler(cfg, repo, aiAgent, logger)
+	toolRegistry := tools.NewRegistry(
+		tools.NewSearchPeople(searchClient),
+		tools.NewSearchProperties(searchClient),
+		tools.NewGetAgentProfile(repo),
+		tools.NewSaveNote(repo),
+	)
+	aiAgent := ai.New(ollamaClient, toolRegistry, cfg.SystemPrompt, logger)
+
+	chatHandler := handler.NewChatHandler(cfg, repo, aiAgent, ollamaClient, profileClient, logger)
+	startersHandler := handler.NewPromptStartersHandler(repo, aiAgent, logger)
 
 	r := chi.NewRouter()
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
-	r.Post("/api/chat", chatHandler.HandleChat)
+	r.Group(func(r chi.Router) {
+		if verifier, ok := newVerifier(cfg, logger); ok {
+			r.Use(verifier.Authenticate)
+		}
+
+		r.Post("/api/chat", chatHandler.HandleChat)
+		r.Post("/api/chat/stream", chatHandler.HandleChatStream)
+		r.Post("/api/prompt-starters", startersHandler.HandlePromptStarters)
+	})
+
+	if cfg.DevAuthEnabled {
+		r.Post("/auth/token", handler.NewDevTokenHandler(cfg.JWTSecret, logger).HandleMint)
+	}
 
 	return &Server{router: r}
 }
 
+// newVerifier builds the JWT verifier for protected routes. Auth is left
+// disabled when no secret or public key is configured, so local development
+// without AUTH_JWT_SECRET keeps working unauthenticated.
+func newVerifier(cfg *config.Config, logger zerolog.Logger) (*authmw.Verifier, bool) {
+	if cfg.JWTSecret == "" && cfg.JWKSURL == "" && cfg.JWTPubKeyPath == "" {
+		logger.Warn().Msg("AUTH_JWT_SECRET/AUTH_JWKS_URL/AUTH_JWT_PUBKEY_PATH not set, running without authentication")
+		return nil, false
+	}
+
+	verifier, err := authmw.NewVerifier(cfg.JWTSecret, cfg.JWKSURL, cfg.JWTPubKeyPath)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to initialise JWT verifier")
+	}
+	return verifier, true
+}
+
 func (s *Server) Router() *chi.Mux {
 	return s.router
 }
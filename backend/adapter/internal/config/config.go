@@ -1,22 +1,43 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+)
 
 type Config struct {
-	ListenAddr   string
-	DatabasePath string
-	OllamaURL    string
-	SearchURL    string
-	SystemPrompt string
+	ListenAddr        string
+	DatabasePath      string
+	OllamaURL         string
+	OllamaModel       string
+	OllamaEmbedModel  string
+	SearchURL         string
+	ProfileURL        string
+	SystemPrompt      string
+	JWTSecret         string
+	JWKSURL           string
+	JWTPubKeyPath     string
+	DevAuthEnabled    bool
+	RecentHistorySize int
+	SimilarTurnsSize  int
 }
 
 func Load() (*Config, error) {
 	return &Config{
-		ListenAddr:   envOr("ADAPTER_LISTEN_ADDR", ":8080"),
-		DatabasePath: envOr("ADAPTER_DB_PATH", "adapter.db"),
-		OllamaURL:    envOr("OLLAMA_URL", "http://localhost:11434"),
-		SearchURL:    envOr("SEARCH_URL", "http://localhost:8090"),
-		SystemPrompt: envOr("SYSTEM_PROMPT", defaultPrompt),
+		ListenAddr:        envOr("ADAPTER_LISTEN_ADDR", ":8080"),
+		DatabasePath:      envOr("ADAPTER_DB_PATH", "adapter.db"),
+		OllamaURL:         envOr("OLLAMA_URL", "http://localhost:11434"),
+		OllamaModel:       envOr("OLLAMA_MODEL", "llama3"),
+		OllamaEmbedModel:  envOr("OLLAMA_EMBED_MODEL", "nomic-embed-text"),
+		SearchURL:         envOr("SEARCH_URL", "http://localhost:8090"),
+		ProfileURL:        envOr("PROFILE_URL", "http://localhost:8081"),
+		SystemPrompt:      envOr("SYSTEM_PROMPT", defaultPrompt),
+		JWTSecret:         envOr("AUTH_JWT_SECRET", ""),
+		JWKSURL:           envOr("AUTH_JWKS_URL", ""),
+		JWTPubKeyPath:     envOr("AUTH_JWT_PUBKEY_PATH", ""),
+		DevAuthEnabled:    envOr("AUTH_DEV_TOKEN_ENDPOINT", "") == "true",
+		RecentHistorySize: envIntOr("CHAT_RECENT_HISTORY_SIZE", 5),
+		SimilarTurnsSize:  envIntOr("CHAT_SIMILAR_TURNS_SIZE", 3),
 	}, nil
 }
 
@@ -27,16 +48,24 @@ func envOr(key, fallback string) string {
 	return fallback
 }
 
+func envIntOr(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return fallback
+}
+
 var defaultPrompt = `
 You are a helpful assistant supporting real estate agent {{.AgentName}}.
 They work in {{.Location}} and have recently listed: {{.Listings}}.
 
-Maintain context across the chat using your memory.
-
-If you need live homeowner or prospect data, respond ONLY with:
-FETCH: <search terms to send to the data service>
-Otherwise, answer normally.
+Use the search_people and search_properties tools when you need live homeowner
+or prospect data instead of guessing, get_agent_profile if you need the agent's
+own details, and save_note to record something worth remembering. Call a tool
+again with refined terms if the first result set isn't useful, then answer the
+agent's question directly.
 
 {{.ConversationHistory}}
-Question: {{.Question}}
 `
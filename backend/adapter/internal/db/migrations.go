@@ -0,0 +1,24 @@
+package db
+
+import "database/sql"
+
+const migration = `
+CREATE TABLE IF NOT EXISTS agent_profiles (
+  agent_id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  location TEXT NOT NULL,
+  listings TEXT NOT NULL DEFAULT '[]'
+);
+
+CREATE TABLE IF NOT EXISTS agent_notes (
+  id TEXT PRIMARY KEY,
+  agent_id TEXT NOT NULL,
+  note TEXT NOT NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+`
+
+func ApplyMigrations(db *sql.DB) error {
+	_, err := db.Exec(migration)
+	return err
+}
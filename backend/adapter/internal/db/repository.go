@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"chat-agent/backend/adapter/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{DB: db}
+}
+
+func (r *Repository) GetAgentProfile(ctx context.Context, agentID string) (*models.AgentProfile, error) {
+	var profile models.AgentProfile
+	profile.AgentID = agentID
+
+	var listingsJSON string
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT name, location, listings FROM agent_profiles WHERE agent_id = ?`, agentID).
+		Scan(&profile.Name, &profile.Location, &listingsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if listingsJSON != "" {
+		if err := json.Unmarshal([]byte(listingsJSON), &profile.Listings); err != nil {
+			return nil, err
+		}
+	}
+
+	return &profile, nil
+}
+
+// SaveNote records a free-text note against an agent, for the save_note
+// tool to call mid-conversation.
+func (r *Repository) SaveNote(ctx context.Context, agentID, note string) error {
+	_, err := r.DB.ExecContext(ctx,
+		`INSERT INTO agent_notes (id, agent_id, note) VALUES (?, ?, ?)`,
+		uuid.NewString(), agentID, note,
+	)
+	return err
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -11,26 +14,36 @@ import (
 	"chat-agent/backend/adapter/internal/config"
 	"chat-agent/backend/adapter/internal/db"
 	"chat-agent/backend/adapter/internal/models"
+	"chat-agent/backend/adapter/internal/ollama"
+	"chat-agent/backend/adapter/internal/profile"
+	"chat-agent/backend/adapter/internal/tools"
+	authmw "chat-agent/backend/common/auth"
 )
 
 type ChatHandler struct {
-	cfg    *config.Config
-	repo   *db.Repository
-	agent  *ai.Agent
-	logger zerolog.Logger
+	cfg     *config.Config
+	repo    *db.Repository
+	agent   *ai.Agent
+	ollama  *ollama.Client
+	profile *profile.Client
+	logger  zerolog.Logger
 }
 
 func NewChatHandler(
 	cfg *config.Config,
 	repo *db.Repository,
 	agent *ai.Agent,
+	ollamaClient *ollama.Client,
+	profileClient *profile.Client,
 	logger zerolog.Logger,
 ) *ChatHandler {
 	return &ChatHandler{
-		cfg:    cfg,
-		repo:   repo,
-		agent:  agent,
-		logger: logger,
+		cfg:     cfg,
+		repo:    repo,
+		agent:   agent,
+		ollama:  ollamaClient,
+		profile: profileClient,
+		logger:  logger,
 	}
 }
 
@@ -57,28 +70,51 @@ func (h *ChatHandler) HandleChat(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid payload", http.StatusBadRequest)
 		return
 	}
+	if !authmw.EnforceAgentID(w, r, req.AgentID) {
+		return
+	}
 
-	ctx := r.Context()
+	ctx := tools.ContextWithAgentID(r.Context(), req.AgentID)
+
+	agentProfile, err := h.repo.GetAgentProfile(ctx, req.AgentID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("agent_id", req.AgentID).Msg("failed to load profile")
+		http.Error(w, "profile not found", http.StatusNotFound)
+		return
+	}
 
+	if acceptsEventStream(r) {
+		streamCtx, reset, stop := newStreamContext(ctx, r)
+		defer stop()
+		h.handleChatSSE(w, streamCtx, reset, req, agentProfile)
+		return
+	}
+	h.handleChatJSON(w, ctx, req, agentProfile)
+}
+
+// acceptsEventStream reports whether the client asked for SSE via an
+// Accept: text/event-stream header, as opposed to the default JSON response.
+func acceptsEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+func (h *ChatHandler) handleChatJSON(w http.ResponseWriter, ctx context.Context, req chatRequest, agentProfile *models.AgentProfile) {
 	aiReq := ai.ChatRequest{
 		Messages: []ai.Message{
 			{Role: "user", Content: req.Message},
 		},
 	}
 
-	profile, err := h.repo.GetAgentProfile(ctx, req.AgentID)
-	if err != nil {
-		h.logger.Error().Err(err).Str("agent_id", req.AgentID).Msg("failed to load profile")
-		http.Error(w, "profile not found", http.StatusNotFound)
-		return
-	}
+	history, embedding := h.conversationHistory(ctx, req.AgentID, req.Message)
 
-	reply, err := h.agent.Chat(ctx, aiReq, profile)
+	start := time.Now()
+	reply, err := h.agent.Chat(ctx, aiReq, agentProfile, history)
 	if err != nil {
 		h.logger.Error().Err(err).Msg("ai chat failed")
 		http.Error(w, "failed to contact model", http.StatusBadGateway)
 		return
 	}
+	latencyMS := time.Since(start).Milliseconds()
 
 	now := time.Now().UTC()
 	resp := chatResponse{
@@ -90,12 +126,7 @@ func (h *ChatHandler) HandleChat(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	conversation := models.Conversation{
-		AgentID:  req.AgentID,
-		Query:    req.Message,
-		Response: reply.Message.Content,
-	}
-	if err := h.repo.SaveConversation(ctx, &conversation); err != nil {
+	if _, err := h.profile.SaveTurn(ctx, req.AgentID, req.Message, reply.Message.Content, latencyMS, embedding); err != nil {
 		h.logger.Warn().Err(err).Msg("failed to persist conversation")
 	}
 
@@ -103,7 +134,112 @@ func (h *ChatHandler) HandleChat(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-func composePrompt(system string, profile *models.AgentProfile) string {
-	profileJSON, _ := json.Marshal(profile)
-	return system + "\n\nAgent profile:\n" + string(profileJSON)
+// handleChatSSE streams the model's reply token-by-token as Server-Sent
+// Events, driving the same tool-calling loop and conversation history as
+// handleChatJSON so a streamed answer isn't built from different context
+// than a non-streamed one. ctx is expected to already carry the idle/deadline
+// behavior set up by newStreamContext, with reset called on every chunk
+// received; the client disconnecting or either timeout firing cancels the
+// upstream Ollama request and any in-flight tool calls. The fully assembled
+// response is still persisted to the profile service once the stream ends
+// cleanly.
+func (h *ChatHandler) handleChatSSE(w http.ResponseWriter, ctx context.Context, reset func(), req chatRequest, agentProfile *models.AgentProfile) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	aiReq := ai.ChatRequest{
+		Messages: []ai.Message{
+			{Role: "user", Content: req.Message},
+		},
+	}
+	history, embedding := h.conversationHistory(ctx, req.AgentID, req.Message)
+
+	start := time.Now()
+	chunks, err := h.agent.ChatStream(ctx, aiReq, agentProfile, history)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("ai chat stream failed")
+		http.Error(w, "failed to contact model", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	var final *ai.ChatResponse
+	for chunk := range chunks {
+		reset()
+		if chunk.Err != nil {
+			h.logger.Error().Err(chunk.Err).Msg("ai chat stream failed")
+			break
+		}
+		if chunk.Delta != "" {
+			writeSSE(w, streamEvent{Delta: chunk.Delta})
+			flusher.Flush()
+		}
+		if chunk.Done {
+			final = chunk.Final
+			break
+		}
+	}
+
+	if ctx.Err() != nil || final == nil {
+		return
+	}
+
+	conversationID, err := h.profile.SaveTurn(ctx, req.AgentID, req.Message, final.Message.Content, time.Since(start).Milliseconds(), embedding)
+	if err != nil {
+		h.logger.Warn().Err(err).Msg("failed to persist conversation")
+	}
+
+	writeSSE(w, streamEvent{Done: true, ConversationID: conversationID})
+	flusher.Flush()
+}
+
+// conversationHistory assembles the agent's recent and semantically similar
+// past turns into a block of text for the system prompt. The profile
+// service is best-effort here: if it's unreachable or the agent has no
+// history yet, the chat still proceeds with an empty history rather than
+// failing the request. It also returns the query's embedding, computed once
+// here to rank similar turns, so callers can persist it alongside the turn
+// instead of embedding the same query twice.
+func (h *ChatHandler) conversationHistory(ctx context.Context, agentID, query string) (string, []float32) {
+	if h.profile == nil {
+		return "", nil
+	}
+
+	var sections []string
+
+	if recent, err := h.profile.FetchRecentTurns(ctx, agentID, h.cfg.RecentHistorySize); err != nil {
+		h.logger.Warn().Err(err).Str("agent_id", agentID).Msg("failed to fetch recent conversations")
+	} else if len(recent) > 0 {
+		sections = append(sections, "Recent conversation:\n"+formatTurns(recent))
+	}
+
+	embedding, err := h.ollama.Embed(ctx, query)
+	if err != nil {
+		h.logger.Warn().Err(err).Str("agent_id", agentID).Msg("failed to embed query for memory search")
+		return strings.Join(sections, "\n\n"), nil
+	}
+
+	similar, err := h.profile.FetchSimilarTurns(ctx, agentID, embedding, h.cfg.SimilarTurnsSize)
+	if err != nil {
+		h.logger.Warn().Err(err).Str("agent_id", agentID).Msg("failed to fetch similar conversations")
+	} else if len(similar) > 0 {
+		sections = append(sections, "Related past conversation:\n"+formatTurns(similar))
+	}
+
+	return strings.Join(sections, "\n\n"), embedding
+}
+
+func formatTurns(turns []profile.Turn) string {
+	var b strings.Builder
+	for _, t := range turns {
+		fmt.Fprintf(&b, "Agent: %s\nAssistant: %s\n", t.Query, t.Answer)
+	}
+	return b.String()
 }
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"chat-agent/backend/common/auth"
+)
+
+// DevTokenHandler mints HS256-signed tokens for local testing. It must only
+// be mounted when AUTH_DEV_TOKEN_ENDPOINT is explicitly enabled -- never in
+// production, since it skips credential verification entirely.
+type DevTokenHandler struct {
+	issuer *auth.Issuer
+	logger zerolog.Logger
+}
+
+func NewDevTokenHandler(secret string, logger zerolog.Logger) *DevTokenHandler {
+	return &DevTokenHandler{issuer: auth.NewIssuer(secret), logger: logger}
+}
+
+type mintTokenRequest struct {
+	AgentID  string   `json:"agentId"`
+	AgentIDs []string `json:"agentIds,omitempty"`
+	Role     string   `json:"role"`
+	TTLMin   int      `json:"ttlMinutes"`
+}
+
+func (h *DevTokenHandler) HandleMint(w http.ResponseWriter, r *http.Request) {
+	var req mintTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if req.AgentID == "" {
+		http.Error(w, "agentId is required", http.StatusBadRequest)
+		return
+	}
+	if req.TTLMin <= 0 {
+		req.TTLMin = 60
+	}
+
+	// agentIds lets a dev token be scoped to more than one agent; it
+	// defaults to just agentId for the common single-agent case.
+	agentIDs := req.AgentIDs
+	if len(agentIDs) == 0 {
+		agentIDs = []string{req.AgentID}
+	}
+
+	token, err := h.issuer.Issue(req.AgentID, agentIDs, req.Role, time.Duration(req.TTLMin)*time.Minute)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to sign dev token")
+		http.Error(w, "failed to mint token", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
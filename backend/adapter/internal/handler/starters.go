@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"chat-agent/backend/adapter/internal/ai"
+	"chat-agent/backend/adapter/internal/db"
+	"chat-agent/backend/adapter/internal/models"
+	authmw "chat-agent/backend/common/auth"
+)
+
+// starterCacheTTL controls how long a generated starter list is reused
+// before the model is asked again.
+const starterCacheTTL = 10 * time.Minute
+
+// fallbackStarters is returned when the model fails or its output can't be
+// parsed, so the UI always has something to show.
+var fallbackStarters = []string{
+	"What's happening with property prices in my area?",
+	"Do you have any new listings I should know about?",
+	"How long does it usually take to sell a home like mine?",
+	"What's my home worth in today's market?",
+	"Can you tell me about recent sales nearby?",
+}
+
+type starterCacheEntry struct {
+	starters  []string
+	expiresAt time.Time
+}
+
+// PromptStartersHandler generates suggested opening questions for an
+// agent's chat widget, caching results in-memory per agent+profile so
+// repeated calls don't re-hit the model.
+type PromptStartersHandler struct {
+	repo   *db.Repository
+	agent  *ai.Agent
+	logger zerolog.Logger
+
+	mu    sync.Mutex
+	cache map[string]starterCacheEntry
+}
+
+func NewPromptStartersHandler(repo *db.Repository, agent *ai.Agent, logger zerolog.Logger) *PromptStartersHandler {
+	return &PromptStartersHandler{
+		repo:   repo,
+		agent:  agent,
+		logger: logger,
+		cache:  make(map[string]starterCacheEntry),
+	}
+}
+
+type promptStartersRequest struct {
+	AgentID string `json:"agentId"`
+	Limit   int    `json:"limit"`
+}
+
+func (h *PromptStartersHandler) HandlePromptStarters(w http.ResponseWriter, r *http.Request) {
+	var req promptStartersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if req.AgentID == "" {
+		http.Error(w, "agentId is required", http.StatusBadRequest)
+		return
+	}
+	if !authmw.EnforceAgentID(w, r, req.AgentID) {
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 10 {
+		req.Limit = 5
+	}
+
+	ctx := r.Context()
+
+	profile, err := h.repo.GetAgentProfile(ctx, req.AgentID)
+	if err != nil {
+		h.logger.Warn().Err(err).Str("agent_id", req.AgentID).Msg("failed to load profile for prompt starters")
+		h.writeStarters(w, fallbackStarters, req.Limit)
+		return
+	}
+
+	cacheKey := req.AgentID + ":" + profileHash(profile)
+	if cached, ok := h.cached(cacheKey); ok {
+		h.writeStarters(w, cached, req.Limit)
+		return
+	}
+
+	starters, err := h.agent.Starters(ctx, profile, req.Limit)
+	if err != nil || len(starters) == 0 {
+		h.logger.Warn().Err(err).Str("agent_id", req.AgentID).Msg("falling back to static prompt starters")
+		h.writeStarters(w, fallbackStarters, req.Limit)
+		return
+	}
+
+	h.store(cacheKey, starters)
+	h.writeStarters(w, starters, req.Limit)
+}
+
+func (h *PromptStartersHandler) cached(key string) ([]string, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry, ok := h.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.starters, true
+}
+
+func (h *PromptStartersHandler) store(key string, starters []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.cache[key] = starterCacheEntry{
+		starters:  starters,
+		expiresAt: time.Now().Add(starterCacheTTL),
+	}
+}
+
+func (h *PromptStartersHandler) writeStarters(w http.ResponseWriter, starters []string, limit int) {
+	if len(starters) > limit {
+		starters = starters[:limit]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"starters": starters})
+}
+
+// profileHash fingerprints the parts of a profile that affect the generated
+// starters, so the cache invalidates itself when listings or location change.
+func profileHash(profile *models.AgentProfile) string {
+	sum := sha256.Sum256([]byte(profile.Name + "|" + profile.Location + "|" + strings.Join(profile.Listings, ",")))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"chat-agent/backend/adapter/internal/tools"
+	authmw "chat-agent/backend/common/auth"
+)
+
+// defaultIdleTimeout closes the stream if the model goes quiet for this long
+// between chunks, so a stalled upstream doesn't pin the connection open.
+const defaultIdleTimeout = 30 * time.Second
+
+type streamEvent struct {
+	Delta          string `json:"delta,omitempty"`
+	Done           bool   `json:"done,omitempty"`
+	ConversationID string `json:"conversationId,omitempty"`
+}
+
+// HandleChatStream is the dedicated streaming endpoint, equivalent to
+// POST /api/chat with Accept: text/event-stream except that it streams
+// whatever the Accept header says. Both paths go through newStreamContext,
+// so both honor a per-request deadline (via the Deadline header or a
+// deadline query param) and the idle timeout. It shares handleChatSSE with
+// that Accept-header path -- same tool loop, same conversation history, same
+// persistence -- so the two never diverge in what context an answer is built
+// from.
+func (h *ChatHandler) HandleChatStream(w http.ResponseWriter, r *http.Request) {
+	var req chatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if !authmw.EnforceAgentID(w, r, req.AgentID) {
+		return
+	}
+
+	ctx := tools.ContextWithAgentID(r.Context(), req.AgentID)
+
+	agentProfile, err := h.repo.GetAgentProfile(ctx, req.AgentID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("agent_id", req.AgentID).Msg("failed to load profile")
+		http.Error(w, "profile not found", http.StatusNotFound)
+		return
+	}
+
+	streamCtx, reset, stop := newStreamContext(ctx, r)
+	defer stop()
+
+	h.handleChatSSE(w, streamCtx, reset, req, agentProfile)
+}
+
+// newStreamContext derives a context for a streamed chat response: an
+// optional per-request deadline (Deadline header or query param, RFC3339 or
+// a relative number of seconds) bounds the whole stream, and an idle timer --
+// reset via the returned reset func on every chunk received -- cancels it if
+// the model goes quiet for defaultIdleTimeout. The returned stop func must be
+// deferred by the caller to release the idle timer and cancel the context.
+func newStreamContext(ctx context.Context, r *http.Request) (streamCtx context.Context, reset func(), stop func()) {
+	var deadlineCancel context.CancelFunc
+	if deadline, ok := parseDeadline(r); ok {
+		ctx, deadlineCancel = context.WithDeadline(ctx, deadline)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	idleTimer := time.AfterFunc(defaultIdleTimeout, cancel)
+
+	reset = func() { idleTimer.Reset(defaultIdleTimeout) }
+	stop = func() {
+		idleTimer.Stop()
+		cancel()
+		if deadlineCancel != nil {
+			deadlineCancel()
+		}
+	}
+	return ctx, reset, stop
+}
+
+func writeSSE(w http.ResponseWriter, event streamEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", payload)
+}
+
+// parseDeadline reads a per-request deadline from the Deadline header or
+// query param, either as an RFC3339 timestamp or a relative number of
+// seconds from now.
+func parseDeadline(r *http.Request) (time.Time, bool) {
+	raw := r.Header.Get("Deadline")
+	if raw == "" {
+		raw = r.URL.Query().Get("deadline")
+	}
+	if raw == "" {
+		return time.Time{}, false
+	}
+
+	if ts, err := time.Parse(time.RFC3339, raw); err == nil {
+		return ts, true
+	}
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second), true
+	}
+	return time.Time{}, false
+}
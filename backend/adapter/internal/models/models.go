@@ -0,0 +1,12 @@
+package models
+
+// AgentProfile is the adapter's local view of a real estate agent, read from
+// the adapter's own agent_profiles table so the chat agent can render
+// prompts without a network round-trip on every turn. Nothing in the adapter
+// copies profiles into that table from the profile service.
+type AgentProfile struct {
+	AgentID  string   `json:"agent_id"`
+	Name     string   `json:"name"`
+	Location string   `json:"location"`
+	Listings []string `json:"listings"`
+}
@@ -1,6 +1,7 @@
 package ollama
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -10,24 +11,35 @@ import (
 )
 
 type Client struct {
-	baseURL string
-	http    *http.Client
-	model   string
+	baseURL    string
+	http       *http.Client
+	streamHTTP *http.Client
+	model      string
+	embedModel string
 }
 
-func New(baseURL, model string) *Client {
+func New(baseURL, model, embedModel string) *Client {
 	return &Client{
-		baseURL: baseURL,
-		model:   model,
+		baseURL:    baseURL,
+		model:      model,
+		embedModel: embedModel,
 		http: &http.Client{
 			Timeout: 55 * time.Second,
 		},
+		// ChatStream's response body is read incrementally over the life of
+		// the whole reply, which can easily run past 55s -- a client-level
+		// Timeout covers that entire read, so it would cut the stream off
+		// mid-answer. Cancellation for a stream is the caller's ctx instead
+		// (see handler.newStreamContext's deadline/idle timeout).
+		streamHTTP: &http.Client{},
 	}
 }
 
 type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
 }
 
 type FunctionTool struct {
@@ -55,9 +67,9 @@ type ChoiceMessage struct {
 }
 
 type ToolCall struct {
-	ID       string            `json:"id"`
-	Type     string            `json:"type"`
-	Function ToolCallFunction  `json:"function"`
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function ToolCallFunction `json:"function"`
 }
 
 type ToolCallFunction struct {
@@ -102,3 +114,113 @@ func (c *Client) Chat(ctx context.Context, req ChatRequest) (*ChatResponse, erro
 	return &payload, nil
 }
 
+// ChatStreamChunk is a single line of the newline-delimited JSON stream
+// Ollama emits for /api/chat when Stream is true.
+type ChatStreamChunk struct {
+	Message ChoiceMessage `json:"message"`
+	Done    bool          `json:"done"`
+}
+
+// ChatStream opens a streaming chat request and decodes Ollama's
+// newline-delimited JSON chunks onto the returned channel. The channel is
+// closed when the model reports it is done, the stream ends, or ctx is
+// cancelled; callers should range over it and check ctx.Err() afterwards to
+// distinguish a clean finish from cancellation.
+func (c *Client) ChatStream(ctx context.Context, req ChatRequest) (<-chan ChatStreamChunk, error) {
+	req.Model = c.model
+	req.Stream = true
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/chat", c.baseURL), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.streamHTTP.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("ollama error: %s", resp.Status)
+	}
+
+	chunks := make(chan ChatStreamChunk)
+	go func() {
+		defer close(chunks)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+
+			var chunk ChatStreamChunk
+			if err := json.Unmarshal(line, &chunk); err != nil {
+				return
+			}
+
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+				return
+			}
+
+			if chunk.Done {
+				return
+			}
+		}
+	}()
+
+	return chunks, nil
+}
+
+type embedRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+type embedResponse struct {
+	Embedding []float32 `json:"embedding"`
+}
+
+// Embed computes a vector embedding for text using Ollama's embedding
+// model, for ranking prior conversation turns by semantic similarity.
+func (c *Client) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(embedRequest{Model: c.embedModel, Prompt: text})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/embeddings", c.baseURL), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("ollama error: %s", resp.Status)
+	}
+
+	var payload embedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+	return payload.Embedding, nil
+}
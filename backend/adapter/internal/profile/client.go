@@ -0,0 +1,197 @@
+// Package profile is an HTTP client for the profile service's conversation
+// memory endpoints, used by the adapter's chat pipeline to pull prior turns
+// into the system prompt.
+package profile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"chat-agent/backend/common/auth"
+)
+
+// Turn is a single past query/answer pair, as returned by the profile
+// service's recent/search conversation endpoints.
+type Turn struct {
+	Query     string    `json:"query"`
+	Answer    string    `json:"answer"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Client struct {
+	baseURL string
+	http    *http.Client
+	issuer  *auth.Issuer
+}
+
+// NewClient builds a profile service client. jwtSecret, if non-empty, lets
+// the client mint its own short-lived internal tokens (see authorize) so its
+// calls pass the profile service's Authenticate middleware once that's
+// configured -- it must be the same AUTH_JWT_SECRET the profile service
+// verifies against. An empty jwtSecret sends no Authorization header at all,
+// matching the profile service's own fail-open default for unauthenticated
+// local development.
+func NewClient(baseURL, jwtSecret string) *Client {
+	c := &Client{
+		baseURL: baseURL,
+		http: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+	if jwtSecret != "" {
+		c.issuer = auth.NewIssuer(jwtSecret)
+	}
+	return c
+}
+
+// serviceTokenTTL bounds how long an internal token minted for a single
+// profile-service call stays valid -- comfortably longer than any one
+// request, short enough that a leaked token can't be replayed for long.
+const serviceTokenTTL = time.Minute
+
+// authorize mints a short-lived internal token scoped to agentID and sets it
+// as req's bearer token. It's a no-op if no jwtSecret was configured on this
+// client.
+func (c *Client) authorize(req *http.Request, agentID string) error {
+	if c.issuer == nil {
+		return nil
+	}
+	token, err := c.issuer.Issue(agentID, []string{agentID}, "", serviceTokenTTL)
+	if err != nil {
+		return fmt.Errorf("failed to mint service token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// FetchRecentTurns returns an agent's most recent conversation turns,
+// most recent first.
+func (c *Client) FetchRecentTurns(ctx context.Context, agentID string, limit int) ([]Turn, error) {
+	u := fmt.Sprintf("%s/api/conversations/%s/recent?limit=%s", c.baseURL, url.PathEscape(agentID), strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.authorize(req, agentID); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("profile service error: %s", resp.Status)
+	}
+
+	var turns []Turn
+	if err := json.NewDecoder(resp.Body).Decode(&turns); err != nil {
+		return nil, err
+	}
+	return turns, nil
+}
+
+type saveTurnRequest struct {
+	AgentID        string    `json:"agentId"`
+	Query          string    `json:"query"`
+	Response       string    `json:"response"`
+	LatencyMS      int64     `json:"latencyMs,omitempty"`
+	QueryEmbedding []float32 `json:"queryEmbedding,omitempty"`
+}
+
+type saveTurnResponse struct {
+	ConversationID string `json:"conversationId"`
+}
+
+// SaveTurn persists a query/answer turn to the profile service, along with
+// the query's embedding if one was computed, so it's available to later
+// FetchRecentTurns/FetchSimilarTurns calls for this agent, and returns the
+// conversation it was saved under. Each turn starts its own conversation --
+// nothing on the read side groups by conversation, only by agent.
+func (c *Client) SaveTurn(ctx context.Context, agentID, query, answer string, latencyMS int64, queryEmbedding []float32) (string, error) {
+	payload, err := json.Marshal(saveTurnRequest{
+		AgentID:        agentID,
+		Query:          query,
+		Response:       answer,
+		LatencyMS:      latencyMS,
+		QueryEmbedding: queryEmbedding,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	u := fmt.Sprintf("%s/api/conversations", c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := c.authorize(req, agentID); err != nil {
+		return "", err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("profile service error: %s", resp.Status)
+	}
+
+	var parsed saveTurnResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", err
+	}
+	return parsed.ConversationID, nil
+}
+
+type searchTurnsRequest struct {
+	QueryEmbedding []float32 `json:"queryEmbedding"`
+	K              int       `json:"k"`
+}
+
+// FetchSimilarTurns returns an agent's past conversation turns ranked by
+// semantic similarity to queryEmbedding, most similar first.
+func (c *Client) FetchSimilarTurns(ctx context.Context, agentID string, queryEmbedding []float32, k int) ([]Turn, error) {
+	payload, err := json.Marshal(searchTurnsRequest{QueryEmbedding: queryEmbedding, K: k})
+	if err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("%s/api/conversations/%s/search", c.baseURL, url.PathEscape(agentID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := c.authorize(req, agentID); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("profile service error: %s", resp.Status)
+	}
+
+	var turns []Turn
+	if err := json.NewDecoder(resp.Body).Decode(&turns); err != nil {
+		return nil, err
+	}
+	return turns, nil
+}
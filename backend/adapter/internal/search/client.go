@@ -31,6 +31,13 @@ func (c *Client) FetchProperty(ctx context.Context, query string) (json.RawMessa
 	return c.fetch(ctx, "/search/property", query)
 }
 
+// FetchSmart queries the search service's API-backed smart search, which
+// ranks across both people and property data when a third-party API key is
+// configured on that service.
+func (c *Client) FetchSmart(ctx context.Context, query string) (json.RawMessage, error) {
+	return c.fetch(ctx, "/search/smart", query)
+}
+
 func (c *Client) fetch(ctx context.Context, path, query string) (json.RawMessage, error) {
 	u, err := url.Parse(c.baseURL + path)
 	if err != nil {
@@ -61,4 +68,3 @@ func (c *Client) fetch(ctx context.Context, path, query string) (json.RawMessage
 	}
 	return raw, nil
 }
-
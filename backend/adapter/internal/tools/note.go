@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"chat-agent/backend/adapter/internal/db"
+	"chat-agent/backend/adapter/internal/ollama"
+)
+
+type saveNoteArgs struct {
+	Note string `json:"note"`
+}
+
+// SaveNote records a short free-text note against the calling agent, for
+// details that come up mid-conversation and are worth keeping -- a callback
+// time, a preference the agent mentioned, and so on.
+type SaveNote struct {
+	repo *db.Repository
+}
+
+func NewSaveNote(repo *db.Repository) *SaveNote {
+	return &SaveNote{repo: repo}
+}
+
+func (t *SaveNote) Name() string { return "save_note" }
+
+func (t *SaveNote) Spec() ollama.FunctionTool {
+	return ollama.FunctionTool{
+		Name:        t.Name(),
+		Description: "Save a short free-text note for the current agent to recall later.",
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"note": {"type": "string", "description": "The note text to save"}
+			},
+			"required": ["note"]
+		}`),
+	}
+}
+
+func (t *SaveNote) Call(ctx context.Context, args json.RawMessage) (json.RawMessage, error) {
+	var a saveNoteArgs
+	if err := json.Unmarshal(args, &a); err != nil {
+		return nil, fmt.Errorf("invalid save_note arguments: %w", err)
+	}
+	if a.Note == "" {
+		return nil, fmt.Errorf("save_note: note is required")
+	}
+
+	agentID, ok := AgentIDFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("save_note: no agent in context")
+	}
+
+	if err := t.repo.SaveNote(ctx, agentID, a.Note); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(`{"status": "saved"}`), nil
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"chat-agent/backend/adapter/internal/db"
+	"chat-agent/backend/adapter/internal/ollama"
+)
+
+// GetAgentProfile returns the calling agent's own profile -- name, location,
+// and current listings -- so the model can pull those details up without
+// guessing when the agent asks about their own business.
+type GetAgentProfile struct {
+	repo *db.Repository
+}
+
+func NewGetAgentProfile(repo *db.Repository) *GetAgentProfile {
+	return &GetAgentProfile{repo: repo}
+}
+
+func (t *GetAgentProfile) Name() string { return "get_agent_profile" }
+
+func (t *GetAgentProfile) Spec() ollama.FunctionTool {
+	return ollama.FunctionTool{
+		Name:        t.Name(),
+		Description: "Fetch the current agent's own profile: name, location, and active listings.",
+		Parameters:  json.RawMessage(`{"type": "object", "properties": {}}`),
+	}
+}
+
+func (t *GetAgentProfile) Call(ctx context.Context, _ json.RawMessage) (json.RawMessage, error) {
+	agentID, ok := AgentIDFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("get_agent_profile: no agent in context")
+	}
+
+	profile, err := t.repo.GetAgentProfile(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(profile)
+}
@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"chat-agent/backend/adapter/internal/ollama"
+	"chat-agent/backend/adapter/internal/search"
+)
+
+var querySchema = json.RawMessage(`{
+	"type": "object",
+	"properties": {
+		"query": {"type": "string", "description": "Free-text search terms, e.g. a suburb, name or address"},
+		"index": {"type": "integer", "description": "1-based index into this tool's previous results, to reference one directly (e.g. a follow-up like 'show me more like #3') instead of searching again"}
+	}
+}`)
+
+type searchArgs struct {
+	Query string `json:"query"`
+	Index int    `json:"index"`
+}
+
+// resultPage is the subset of the search service's SearchResultPage needed
+// to cache individual results for later by-index lookup.
+type resultPage struct {
+	Results []json.RawMessage `json:"results"`
+}
+
+// resultCache remembers each agent's most recent results from a search tool,
+// keyed by agentID, so a follow-up turn can reference one by index without
+// the model having to repeat or re-run the original query.
+type resultCache struct {
+	mu      sync.Mutex
+	results map[string][]json.RawMessage
+}
+
+func newResultCache() *resultCache {
+	return &resultCache{results: make(map[string][]json.RawMessage)}
+}
+
+// store parses a tool's raw result-page response and remembers its results
+// for agentID. A response that doesn't look like a result page (e.g. an
+// error payload) is simply not cached.
+func (c *resultCache) store(agentID string, raw json.RawMessage) {
+	var page resultPage
+	if err := json.Unmarshal(raw, &page); err != nil || len(page.Results) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[agentID] = page.Results
+}
+
+// get returns the 1-based indexed result previously stored for agentID.
+func (c *resultCache) get(agentID string, index int) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	results := c.results[agentID]
+	if index < 1 || index > len(results) {
+		return nil, false
+	}
+	return results[index-1], true
+}
+
+// SearchPeople looks up homeowners or prospects matching free-text terms.
+type SearchPeople struct {
+	client *search.Client
+	cache  *resultCache
+}
+
+func NewSearchPeople(client *search.Client) *SearchPeople {
+	return &SearchPeople{client: client, cache: newResultCache()}
+}
+
+func (t *SearchPeople) Name() string { return "search_people" }
+
+func (t *SearchPeople) Spec() ollama.FunctionTool {
+	return ollama.FunctionTool{
+		Name:        t.Name(),
+		Description: "Search for homeowners or prospects matching the given terms, or look up one of this tool's own previous results by index.",
+		Parameters:  querySchema,
+	}
+}
+
+func (t *SearchPeople) Call(ctx context.Context, args json.RawMessage) (json.RawMessage, error) {
+	var a searchArgs
+	if err := json.Unmarshal(args, &a); err != nil {
+		return nil, fmt.Errorf("invalid search_people arguments: %w", err)
+	}
+
+	agentID, _ := AgentIDFromContext(ctx)
+
+	if a.Index > 0 {
+		record, ok := t.cache.get(agentID, a.Index)
+		if !ok {
+			return nil, fmt.Errorf("no result #%d in this agent's last search_people results", a.Index)
+		}
+		return record, nil
+	}
+
+	result, err := t.client.FetchPeople(ctx, a.Query)
+	if err != nil {
+		return nil, err
+	}
+	t.cache.store(agentID, result)
+	return result, nil
+}
+
+// SearchProperties looks up property listings matching free-text terms.
+type SearchProperties struct {
+	client *search.Client
+	cache  *resultCache
+}
+
+func NewSearchProperties(client *search.Client) *SearchProperties {
+	return &SearchProperties{client: client, cache: newResultCache()}
+}
+
+func (t *SearchProperties) Name() string { return "search_properties" }
+
+func (t *SearchProperties) Spec() ollama.FunctionTool {
+	return ollama.FunctionTool{
+		Name:        t.Name(),
+		Description: "Search for property listings matching the given terms, or look up one of this tool's own previous results by index.",
+		Parameters:  querySchema,
+	}
+}
+
+func (t *SearchProperties) Call(ctx context.Context, args json.RawMessage) (json.RawMessage, error) {
+	var a searchArgs
+	if err := json.Unmarshal(args, &a); err != nil {
+		return nil, fmt.Errorf("invalid search_properties arguments: %w", err)
+	}
+
+	agentID, _ := AgentIDFromContext(ctx)
+
+	if a.Index > 0 {
+		record, ok := t.cache.get(agentID, a.Index)
+		if !ok {
+			return nil, fmt.Errorf("no result #%d in this agent's last search_properties results", a.Index)
+		}
+		return record, nil
+	}
+
+	result, err := t.client.FetchProperty(ctx, a.Query)
+	if err != nil {
+		return nil, err
+	}
+	t.cache.store(agentID, result)
+	return result, nil
+}
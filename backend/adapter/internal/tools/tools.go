@@ -0,0 +1,60 @@
+// Package tools defines the structured tool-calling protocol the adapter's
+// chat agent uses to fetch live data and act on the model's behalf, in
+// place of the earlier convention of asking the model to emit a
+// string-prefixed "FETCH: <terms>" line for the handler to parse.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"chat-agent/backend/adapter/internal/ollama"
+)
+
+// Tool is a single function the model can call mid-conversation. Spec
+// describes it to Ollama; Call executes it with the model-supplied
+// arguments and returns a JSON result to feed back as a "tool" message.
+type Tool interface {
+	Name() string
+	Spec() ollama.FunctionTool
+	Call(ctx context.Context, args json.RawMessage) (json.RawMessage, error)
+}
+
+// Registry looks tools up by name for dispatch.
+type Registry map[string]Tool
+
+// NewRegistry indexes tools by name.
+func NewRegistry(tools ...Tool) Registry {
+	reg := make(Registry, len(tools))
+	for _, t := range tools {
+		reg[t.Name()] = t
+	}
+	return reg
+}
+
+// Specs returns the FunctionTool schema for every registered tool, for
+// inclusion in an Ollama chat request's Tools field.
+func (r Registry) Specs() []ollama.FunctionTool {
+	specs := make([]ollama.FunctionTool, 0, len(r))
+	for _, t := range r {
+		specs = append(specs, t.Spec())
+	}
+	return specs
+}
+
+type contextKey string
+
+const agentIDContextKey contextKey = "toolsAgentID"
+
+// ContextWithAgentID attaches the authenticated caller's agentId so tools
+// that act on the caller's own data (get_agent_profile, save_note) can read
+// it without the model having to supply it as an argument.
+func ContextWithAgentID(ctx context.Context, agentID string) context.Context {
+	return context.WithValue(ctx, agentIDContextKey, agentID)
+}
+
+// AgentIDFromContext returns the agentId attached by ContextWithAgentID.
+func AgentIDFromContext(ctx context.Context) (string, bool) {
+	agentID, ok := ctx.Value(agentIDContextKey).(string)
+	return agentID, ok
+}
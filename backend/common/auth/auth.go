@@ -0,0 +1,291 @@
+// Package auth issues and validates the JWTs shared by the adapter,
+// profile, and search services. A token carries the userID it was issued
+// to and the agentIDs[] it's scoped to act on behalf of; a "role" claim of
+// "admin" can act on behalf of any agent regardless of agentIDs.
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RoleAdmin grants a token access to every agentId, bypassing the
+// subject-match check in EnforceAgentID/RequireAgentParam.
+const RoleAdmin = "admin"
+
+// Claims are the JWT claims issued and checked across all three services.
+// Subject carries the userID the token was issued to -- for tokens from
+// /auth/login that's the same value as the one entry in AgentIDs, since
+// user_credentials has no separate user identity (the adapter's dev-only
+// /auth/token endpoint can scope a token to several agentIDs), but keeping
+// them distinct claims matches what a
+// future multi-agent login (one user, several agentIDs) would need without
+// another claim-shape change.
+type Claims struct {
+	UserID   string   `json:"userID"`
+	AgentIDs []string `json:"agentIDs"`
+	Role     string   `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// HasAgentID reports whether the token is scoped to agentID.
+func (c *Claims) HasAgentID(agentID string) bool {
+	for _, id := range c.AgentIDs {
+		if id == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the token's role grants access to every agent.
+func (c *Claims) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
+
+// Issuer mints access and refresh tokens, both HS256-signed with the same
+// shared secret. Tokens are issued by the profile service's /auth/login, the
+// adapter's dev-only /auth/token endpoint, and the adapter's profile client
+// for its own calls to the profile service; all three services can verify
+// them.
+type Issuer struct {
+	secret []byte
+}
+
+// NewIssuer builds an Issuer from an HMAC secret.
+func NewIssuer(hmacSecret string) *Issuer {
+	return &Issuer{secret: []byte(hmacSecret)}
+}
+
+// Issue mints a token for userID, scoped to agentIDs, with the given
+// time-to-live.
+func (i *Issuer) Issue(userID string, agentIDs []string, role string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		UserID:   userID,
+		AgentIDs: agentIDs,
+		Role:     role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(i.secret)
+}
+
+// Verifier validates bearer tokens using an HMAC secret (HS256), a JWKS
+// endpoint (RS256), and/or a static RS256 public key file, whichever were
+// configured.
+type Verifier struct {
+	secret    []byte
+	jwks      *jwksCache
+	staticKey *rsa.PublicKey
+}
+
+// NewVerifier builds a Verifier from an HMAC secret, a JWKS URL, and/or a
+// path to a PEM-encoded RSA public key file. At least one must be non-empty.
+// If both jwksURL and pubKeyPath are given, an RS256 token is verified
+// against the JWKS first, falling back to the static key only if the JWKS
+// has no matching kid.
+func NewVerifier(hmacSecret, jwksURL, pubKeyPath string) (*Verifier, error) {
+	v := &Verifier{secret: []byte(hmacSecret)}
+	if jwksURL != "" {
+		v.jwks = newJWKSCache(jwksURL)
+	}
+	if pubKeyPath != "" {
+		key, err := loadRSAPublicKey(pubKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("auth: failed to load RSA public key: %w", err)
+		}
+		v.staticKey = key
+	}
+	return v, nil
+}
+
+func (v *Verifier) parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA:
+			if v.jwks != nil {
+				kid, _ := t.Header["kid"].(string)
+				if key, err := v.jwks.key(kid); err == nil {
+					return key, nil
+				} else if v.staticKey == nil {
+					return nil, err
+				}
+			}
+			if v.staticKey == nil {
+				return nil, jwt.ErrTokenUnverifiable
+			}
+			return v.staticKey, nil
+		case *jwt.SigningMethodHMAC:
+			if len(v.secret) == 0 {
+				return nil, jwt.ErrTokenUnverifiable
+			}
+			return v.secret, nil
+		default:
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+type contextKey string
+
+const claimsContextKey contextKey = "authClaims"
+
+// Authenticate validates the Authorization: Bearer <jwt> header on every
+// request, rejecting missing/expired/invalid tokens with 401, and stores
+// the parsed Claims on the request context.
+func (v *Verifier) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		tokenString, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || tokenString == "" {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := v.parse(tokenString)
+		if err != nil {
+			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), claimsContextKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ClaimsFromContext returns the authenticated caller's claims, if any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*Claims)
+	return claims, ok
+}
+
+// EnforceAgentID checks the request's authenticated claims (if the route is
+// protected) against an agentId taken from the request path/body, writing a
+// 403 and returning false unless agentID is in the token's agentIDs claim or
+// the token's role is admin. If no claims are present, the request was never
+// routed through Verifier.Authenticate -- i.e. auth is disabled because
+// newVerifier found no AUTH_JWT_SECRET/AUTH_JWKS_URL/AUTH_JWT_PUBKEY_PATH
+// configured, which is only expected in local development -- so the check
+// passes.
+func EnforceAgentID(w http.ResponseWriter, r *http.Request, agentID string) bool {
+	claims, ok := ClaimsFromContext(r.Context())
+	if !ok {
+		return true
+	}
+	if !claims.IsAdmin() && !claims.HasAgentID(agentID) {
+		http.Error(w, "token not permitted for this agent", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// RequireAgentParam rejects the request with 403 unless the chi URL param
+// named paramName is in the caller's token's agentIDs claim, or the token is
+// admin. Like EnforceAgentID, it passes the request through when no claims
+// are present, i.e. when auth is disabled.
+func RequireAgentParam(paramName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			agentID := chi.URLParam(r, paramName)
+			if agentID != "" && !claims.IsAdmin() && !claims.HasAgentID(agentID) {
+				http.Error(w, "token not permitted for this agent", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// loadRSAPublicKey reads and parses a PEM-encoded RSA public key file, for
+// verifying RS256 tokens without depending on a JWKS endpoint being
+// reachable.
+func loadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+}
+
+// jwksCache lazily fetches and caches RSA public keys from a JWKS endpoint,
+// keyed by kid, refetching once on a cache miss in case the signer rotated
+// keys.
+type jwksCache struct {
+	url string
+
+	mu   sync.Mutex
+	keys map[string]*rsa.PublicKey
+}
+
+func newJWKSCache(url string) *jwksCache {
+	return &jwksCache{url: url, keys: make(map[string]*rsa.PublicKey)}
+}
+
+func (c *jwksCache) key(kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	c.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	if err := c.refresh(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key, ok = c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("auth: no JWKS key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+func (c *jwksCache) refresh() error {
+	resp, err := http.Get(c.url)
+	if err != nil {
+		return fmt.Errorf("auth: failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("auth: JWKS endpoint returned %s", resp.Status)
+	}
+
+	keys, err := parseJWKS(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys = keys
+	return nil
+}
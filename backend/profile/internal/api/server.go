@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/cors"
 	"github.com/rs/zerolog"
 
+	authmw "chat-agent/backend/common/auth"
 	"chat-agent/backend/profile/internal/config"
 	"chat-agent/backend/profile/internal/db"
 	"chat-agent/backend/profile/internal/handler"
@@ -18,10 +19,12 @@ type Server struct {
 }
 
 func NewServer(cfg *config.Config, dbConn *sql.DB, logger zerolog.Logger) *Server {
-	repo := db.NewRepository(dbConn)
+	repo := db.NewRepository(dbConn, cfg.ListingsLimit)
 
 	profileHandler := handler.NewProfileHandler(repo, logger)
 	conversationHandler := handler.NewConversationHandler(repo, logger)
+	issuer := authmw.NewIssuer(cfg.JWTSecret)
+	loginHandler := handler.NewLoginHandler(repo, issuer, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, logger)
 
 	r := chi.NewRouter()
 	r.Use(middleware.RealIP)
@@ -36,13 +39,42 @@ func NewServer(cfg *config.Config, dbConn *sql.DB, logger zerolog.Logger) *Serve
 		MaxAge:           300,
 	}))
 
-	r.Get("/api/profile/{agentId}", profileHandler.HandleGetProfile)
-	r.Get("/api/agents", profileHandler.HandleGetAgents)
-	r.Post("/api/conversations", conversationHandler.HandleSaveConversation)
+	r.Post("/auth/login", loginHandler.HandleLogin)
+
+	r.Group(func(r chi.Router) {
+		if verifier, ok := newVerifier(cfg, logger); ok {
+			r.Use(verifier.Authenticate)
+		}
+
+		r.With(authmw.RequireAgentParam("agentId")).Get("/api/profile/{agentId}", profileHandler.HandleGetProfile)
+		r.Get("/api/agents", profileHandler.HandleGetAgents)
+		r.Post("/api/conversations", conversationHandler.HandleSaveConversation)
+		r.Get("/api/conversations", conversationHandler.HandleListConversations)
+		r.Get("/api/conversations/{id}", conversationHandler.HandleGetConversation)
+		r.Delete("/api/conversations/{id}", conversationHandler.HandleDeleteConversation)
+		r.Get("/api/conversations/{agentId}/recent", conversationHandler.HandleGetRecentConversations)
+		r.Post("/api/conversations/{agentId}/search", conversationHandler.HandleSearchConversations)
+	})
 
 	return &Server{router: r}
 }
 
+// newVerifier builds the JWT verifier for protected routes. Auth is left
+// disabled when no secret or public key is configured, so local development
+// without AUTH_JWT_SECRET keeps working unauthenticated.
+func newVerifier(cfg *config.Config, logger zerolog.Logger) (*authmw.Verifier, bool) {
+	if cfg.JWTSecret == "" && cfg.JWKSURL == "" && cfg.JWTPubKeyPath == "" {
+		logger.Warn().Msg("AUTH_JWT_SECRET/AUTH_JWKS_URL/AUTH_JWT_PUBKEY_PATH not set, running without authentication")
+		return nil, false
+	}
+
+	verifier, err := authmw.NewVerifier(cfg.JWTSecret, cfg.JWKSURL, cfg.JWTPubKeyPath)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to initialise JWT verifier")
+	}
+	return verifier, true
+}
+
 func (s *Server) Router() *chi.Mux {
 	return s.router
 }
@@ -3,19 +3,30 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 type Config struct {
-	ListenAddr       string
-	DatabasePath     string
-	ListingsLimit    int
+	ListenAddr      string
+	DatabasePath    string
+	ListingsLimit   int
+	JWTSecret       string
+	JWKSURL         string
+	JWTPubKeyPath   string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
 }
 
 func Load() (*Config, error) {
 	return &Config{
-		ListenAddr:    envOr("PROFILE_LISTEN_ADDR", ":8080"),
-		DatabasePath:   envOr("PROFILE_DB_PATH", "./profile.db"),
-		ListingsLimit: envIntOr("PROFILE_LISTINGS_LIMIT", 5),
+		ListenAddr:      envOr("PROFILE_LISTEN_ADDR", ":8080"),
+		DatabasePath:    envOr("PROFILE_DB_PATH", "./profile.db"),
+		ListingsLimit:   envIntOr("PROFILE_LISTINGS_LIMIT", 5),
+		JWTSecret:       envOr("AUTH_JWT_SECRET", ""),
+		JWKSURL:         envOr("AUTH_JWKS_URL", ""),
+		JWTPubKeyPath:   envOr("AUTH_JWT_PUBKEY_PATH", ""),
+		AccessTokenTTL:  time.Duration(envIntOr("AUTH_ACCESS_TTL_MIN", 15)) * time.Minute,
+		RefreshTokenTTL: time.Duration(envIntOr("AUTH_REFRESH_TTL_MIN", 10080)) * time.Minute,
 	}, nil
 }
 
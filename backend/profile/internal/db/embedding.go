@@ -0,0 +1,50 @@
+package db
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// encodeEmbedding packs a float32 vector into a BLOB-friendly byte slice
+// (little-endian, 4 bytes per dimension) for storage in the embedding
+// column.
+func encodeEmbedding(vec []float32) []byte {
+	buf := make([]byte, 4*len(vec))
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf
+}
+
+// decodeEmbedding unpacks a BLOB written by encodeEmbedding back into a
+// float32 vector. Malformed or empty input decodes to nil.
+func decodeEmbedding(buf []byte) []float32 {
+	if len(buf) == 0 || len(buf)%4 != 0 {
+		return nil
+	}
+	vec := make([]float32, len(buf)/4)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:]))
+	}
+	return vec
+}
+
+// cosineSimilarity scores how similar two equal-length vectors are,
+// ranging from -1 to 1. Mismatched lengths score 0 rather than panicking,
+// since a model/dimension change shouldn't crash retrieval.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
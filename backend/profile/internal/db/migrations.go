@@ -22,12 +22,31 @@ CREATE TABLE IF NOT EXISTS property_listings (
   FOREIGN KEY(agent_id) REFERENCES user_info(agent_id)
 );
 
-CREATE TABLE IF NOT EXISTS llm_conversations (
-  id INTEGER PRIMARY KEY AUTOINCREMENT,
+CREATE TABLE IF NOT EXISTS conversations (
+  id TEXT PRIMARY KEY,
   agent_id TEXT NOT NULL,
+  started_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY(agent_id) REFERENCES user_info(agent_id)
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+  id TEXT PRIMARY KEY,
+  conversation_id TEXT NOT NULL,
   query TEXT NOT NULL,
-  response TEXT NOT NULL,
-  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+  answer TEXT NOT NULL,
+  latency_ms INTEGER NOT NULL DEFAULT 0,
+  embedding BLOB,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY(conversation_id) REFERENCES conversations(id)
+);
+
+CREATE TABLE IF NOT EXISTS user_credentials (
+  agent_id TEXT PRIMARY KEY,
+  password_hash TEXT NOT NULL,
+  role TEXT NOT NULL DEFAULT 'agent',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY(agent_id) REFERENCES user_info(agent_id)
 );
 `
 
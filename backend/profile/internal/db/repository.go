@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"sort"
 	"time"
 
 	"chat-agent/backend/profile/internal/models"
+
+	"github.com/google/uuid"
 )
 
 type Repository struct {
@@ -98,10 +101,231 @@ func (r *Repository) GetAllAgents(ctx context.Context) ([]*models.AgentListItem,
 	return agents, rows.Err()
 }
 
-func (r *Repository) SaveConversation(ctx context.Context, conv *models.Conversation) error {
+// UserCredential is a single row of user_credentials, used to verify login
+// attempts against a bcrypt hash.
+type UserCredential struct {
+	AgentID      string
+	PasswordHash string
+	Role         string
+}
+
+// GetUserCredential looks up the stored credential for an agent, so callers
+// can verify a login attempt's password against PasswordHash.
+func (r *Repository) GetUserCredential(ctx context.Context, agentID string) (*UserCredential, error) {
+	var cred UserCredential
+	cred.AgentID = agentID
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT password_hash, role FROM user_credentials WHERE agent_id = ?`, agentID).
+		Scan(&cred.PasswordHash, &cred.Role)
+	if err != nil {
+		return nil, err
+	}
+	return &cred, nil
+}
+
+// CreateConversation starts a new conversation thread for an agent.
+func (r *Repository) CreateConversation(ctx context.Context, agentID string) (*models.Conversation, error) {
+	now := time.Now().UTC()
+	conv := &models.Conversation{
+		ID:        uuid.NewString(),
+		AgentID:   agentID,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+
+	_, err := r.DB.ExecContext(ctx,
+		`INSERT INTO conversations (id, agent_id, started_at, updated_at) VALUES (?, ?, ?, ?)`,
+		conv.ID, conv.AgentID, conv.StartedAt, conv.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return conv, nil
+}
+
+// AppendMessage records a query/answer pair against an existing
+// conversation and bumps the conversation's updated_at.
+func (r *Repository) AppendMessage(ctx context.Context, conversationID, query, answer string, latencyMS int64) (*models.Message, error) {
+	return r.AppendMessageWithEmbedding(ctx, conversationID, query, answer, latencyMS, nil)
+}
+
+// AppendMessageWithEmbedding is AppendMessage plus a query embedding, stored
+// alongside the row so SearchConversations can rank it by similarity later.
+func (r *Repository) AppendMessageWithEmbedding(ctx context.Context, conversationID, query, answer string, latencyMS int64, embedding []float32) (*models.Message, error) {
+	now := time.Now().UTC()
+	msg := &models.Message{
+		ID:             uuid.NewString(),
+		ConversationID: conversationID,
+		Query:          query,
+		Answer:         answer,
+		LatencyMS:      latencyMS,
+		Embedding:      embedding,
+		CreatedAt:      now,
+	}
+
+	var embeddingBlob []byte
+	if len(embedding) > 0 {
+		embeddingBlob = encodeEmbedding(embedding)
+	}
+
 	_, err := r.DB.ExecContext(ctx,
-		`INSERT INTO llm_conversations (agent_id, query, response) VALUES (?, ?, ?)`,
-		conv.AgentID, conv.Query, conv.Response,
+		`INSERT INTO messages (id, conversation_id, query, answer, latency_ms, embedding, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		msg.ID, msg.ConversationID, msg.Query, msg.Answer, msg.LatencyMS, embeddingBlob, msg.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.DB.ExecContext(ctx,
+		`UPDATE conversations SET updated_at = ? WHERE id = ?`, now, conversationID,
+	); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// GetRecentConversations returns an agent's last limit messages across all
+// of their conversations, most recent first -- the short-term half of chat
+// memory.
+func (r *Repository) GetRecentConversations(ctx context.Context, agentID string, limit int) ([]*models.Message, error) {
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT m.id, m.conversation_id, m.query, m.answer, m.latency_ms, m.created_at
+		 FROM messages m
+		 JOIN conversations c ON c.id = m.conversation_id
+		 WHERE c.agent_id = ?
+		 ORDER BY m.created_at DESC
+		 LIMIT ?`,
+		agentID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*models.Message
+	for rows.Next() {
+		var msg models.Message
+		if err := rows.Scan(&msg.ID, &msg.ConversationID, &msg.Query, &msg.Answer, &msg.LatencyMS, &msg.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &msg)
+	}
+	return messages, rows.Err()
+}
+
+// SearchConversations ranks an agent's past messages by cosine similarity
+// of their stored query embedding against queryEmbedding, returning the top
+// k. SQLite has no vector index, so for the message volumes this service
+// expects (well under ~10k rows per agent) a single in-memory pass is
+// simpler and fast enough than standing up a vector store.
+func (r *Repository) SearchConversations(ctx context.Context, agentID string, queryEmbedding []float32, k int) ([]*models.Message, error) {
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT m.id, m.conversation_id, m.query, m.answer, m.latency_ms, m.embedding, m.created_at
+		 FROM messages m
+		 JOIN conversations c ON c.id = m.conversation_id
+		 WHERE c.agent_id = ? AND m.embedding IS NOT NULL`,
+		agentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type scored struct {
+		msg   *models.Message
+		score float64
+	}
+	var candidates []scored
+
+	for rows.Next() {
+		var msg models.Message
+		var embeddingBlob []byte
+		if err := rows.Scan(&msg.ID, &msg.ConversationID, &msg.Query, &msg.Answer, &msg.LatencyMS, &embeddingBlob, &msg.CreatedAt); err != nil {
+			return nil, err
+		}
+		msg.Embedding = decodeEmbedding(embeddingBlob)
+		candidates = append(candidates, scored{msg: &msg, score: cosineSimilarity(queryEmbedding, msg.Embedding)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	if k > len(candidates) {
+		k = len(candidates)
+	}
+	top := make([]*models.Message, k)
+	for i := 0; i < k; i++ {
+		top[i] = candidates[i].msg
+	}
+	return top, nil
+}
+
+// ListConversations returns conversation summaries for an agent, most
+// recently updated first. Messages are not populated; use GetConversation
+// to load a single thread in full.
+func (r *Repository) ListConversations(ctx context.Context, agentID string) ([]*models.Conversation, error) {
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT id, agent_id, started_at, updated_at FROM conversations WHERE agent_id = ? ORDER BY updated_at DESC`,
+		agentID,
 	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conversations []*models.Conversation
+	for rows.Next() {
+		var conv models.Conversation
+		if err := rows.Scan(&conv.ID, &conv.AgentID, &conv.StartedAt, &conv.UpdatedAt); err != nil {
+			return nil, err
+		}
+		conversations = append(conversations, &conv)
+	}
+	return conversations, rows.Err()
+}
+
+// GetConversation loads a single conversation along with all of its
+// messages, oldest first.
+func (r *Repository) GetConversation(ctx context.Context, id string) (*models.Conversation, error) {
+	var conv models.Conversation
+	err := r.DB.QueryRowContext(ctx,
+		`SELECT id, agent_id, started_at, updated_at FROM conversations WHERE id = ?`, id).
+		Scan(&conv.ID, &conv.AgentID, &conv.StartedAt, &conv.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT id, conversation_id, query, answer, latency_ms, created_at FROM messages WHERE conversation_id = ? ORDER BY created_at ASC`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var msg models.Message
+		if err := rows.Scan(&msg.ID, &msg.ConversationID, &msg.Query, &msg.Answer, &msg.LatencyMS, &msg.CreatedAt); err != nil {
+			return nil, err
+		}
+		conv.Messages = append(conv.Messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &conv, nil
+}
+
+// DeleteConversation removes a conversation and all of its messages.
+func (r *Repository) DeleteConversation(ctx context.Context, id string) error {
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM messages WHERE conversation_id = ?`, id); err != nil {
+		return err
+	}
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM conversations WHERE id = ?`, id)
 	return err
 }
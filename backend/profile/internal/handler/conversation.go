@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/rs/zerolog"
 
+	authmw "chat-agent/backend/common/auth"
 	"chat-agent/backend/profile/internal/db"
-	"chat-agent/backend/profile/internal/models"
 )
 
 type ConversationHandler struct {
@@ -23,11 +27,16 @@ func NewConversationHandler(repo *db.Repository, logger zerolog.Logger) *Convers
 }
 
 type saveConversationRequest struct {
-	AgentID  string `json:"agentId"`
-	Query    string `json:"query"`
-	Response string `json:"response"`
+	AgentID        string    `json:"agentId"`
+	ConversationID string    `json:"conversationId,omitempty"`
+	Query          string    `json:"query"`
+	Response       string    `json:"response"`
+	LatencyMS      int64     `json:"latencyMs,omitempty"`
+	QueryEmbedding []float32 `json:"queryEmbedding,omitempty"`
 }
 
+// HandleSaveConversation appends a query/answer pair to a conversation,
+// starting a new conversation thread if conversationId is omitted.
 func (h *ConversationHandler) HandleSaveConversation(w http.ResponseWriter, r *http.Request) {
 	var req saveConversationRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -39,16 +48,25 @@ func (h *ConversationHandler) HandleSaveConversation(w http.ResponseWriter, r *h
 		http.Error(w, "agentId, query, and response are required", http.StatusBadRequest)
 		return
 	}
+	if !authmw.EnforceAgentID(w, r, req.AgentID) {
+		return
+	}
 
 	ctx := r.Context()
 
-	conversation := models.Conversation{
-		AgentID:  req.AgentID,
-		Query:    req.Query,
-		Response: req.Response,
+	conversationID := req.ConversationID
+	if conversationID == "" {
+		conv, err := h.repo.CreateConversation(ctx, req.AgentID)
+		if err != nil {
+			h.logger.Error().Err(err).Msg("failed to create conversation")
+			http.Error(w, "failed to save conversation", http.StatusInternalServerError)
+			return
+		}
+		conversationID = conv.ID
 	}
 
-	if err := h.repo.SaveConversation(ctx, &conversation); err != nil {
+	msg, err := h.repo.AppendMessageWithEmbedding(ctx, conversationID, req.Query, req.Response, req.LatencyMS, req.QueryEmbedding)
+	if err != nil {
 		h.logger.Error().Err(err).Msg("failed to save conversation")
 		http.Error(w, "failed to save conversation", http.StatusInternalServerError)
 		return
@@ -56,6 +74,160 @@ func (h *ConversationHandler) HandleSaveConversation(w http.ResponseWriter, r *h
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+	json.NewEncoder(w).Encode(map[string]string{
+		"status":         "success",
+		"conversationId": conversationID,
+		"messageId":      msg.ID,
+	})
+}
+
+// HandleListConversations lists conversation summaries for an agent.
+func (h *ConversationHandler) HandleListConversations(w http.ResponseWriter, r *http.Request) {
+	agentID := r.URL.Query().Get("agentId")
+	if agentID == "" {
+		http.Error(w, "agentId is required", http.StatusBadRequest)
+		return
+	}
+	if !authmw.EnforceAgentID(w, r, agentID) {
+		return
+	}
+
+	conversations, err := h.repo.ListConversations(r.Context(), agentID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("agent_id", agentID).Msg("failed to list conversations")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(conversations)
+}
+
+// HandleGetConversation returns a single conversation with all its messages.
+func (h *ConversationHandler) HandleGetConversation(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	conversation, err := h.repo.GetConversation(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "conversation not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error().Err(err).Str("conversation_id", id).Msg("failed to load conversation")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !authmw.EnforceAgentID(w, r, conversation.AgentID) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(conversation)
+}
+
+// defaultRecentLimit bounds how many recent turns HandleGetRecentConversations
+// returns when the caller doesn't specify a limit.
+const defaultRecentLimit = 10
+
+// HandleGetRecentConversations returns an agent's most recent messages
+// across all conversations -- the short-term half of chat memory.
+func (h *ConversationHandler) HandleGetRecentConversations(w http.ResponseWriter, r *http.Request) {
+	agentID := chi.URLParam(r, "agentId")
+	if agentID == "" {
+		http.Error(w, "agentId is required", http.StatusBadRequest)
+		return
+	}
+	if !authmw.EnforceAgentID(w, r, agentID) {
+		return
+	}
+
+	limit := defaultRecentLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	messages, err := h.repo.GetRecentConversations(r.Context(), agentID, limit)
+	if err != nil {
+		h.logger.Error().Err(err).Str("agent_id", agentID).Msg("failed to load recent conversations")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(messages)
+}
+
+type searchConversationsRequest struct {
+	QueryEmbedding []float32 `json:"queryEmbedding"`
+	K              int       `json:"k"`
+}
+
+// defaultSearchK bounds how many similar turns HandleSearchConversations
+// returns when the caller doesn't specify k.
+const defaultSearchK = 5
+
+// HandleSearchConversations ranks an agent's past messages by semantic
+// similarity to the given query embedding, returning the top k -- the
+// long-term half of chat memory.
+func (h *ConversationHandler) HandleSearchConversations(w http.ResponseWriter, r *http.Request) {
+	agentID := chi.URLParam(r, "agentId")
+	if agentID == "" {
+		http.Error(w, "agentId is required", http.StatusBadRequest)
+		return
+	}
+	if !authmw.EnforceAgentID(w, r, agentID) {
+		return
+	}
+
+	var req searchConversationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if len(req.QueryEmbedding) == 0 {
+		http.Error(w, "queryEmbedding is required", http.StatusBadRequest)
+		return
+	}
+	if req.K <= 0 {
+		req.K = defaultSearchK
+	}
+
+	messages, err := h.repo.SearchConversations(r.Context(), agentID, req.QueryEmbedding, req.K)
+	if err != nil {
+		h.logger.Error().Err(err).Str("agent_id", agentID).Msg("failed to search conversations")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(messages)
 }
 
+// HandleDeleteConversation deletes a conversation and its messages.
+func (h *ConversationHandler) HandleDeleteConversation(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	conversation, err := h.repo.GetConversation(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "conversation not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error().Err(err).Str("conversation_id", id).Msg("failed to load conversation for delete")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !authmw.EnforceAgentID(w, r, conversation.AgentID) {
+		return
+	}
+
+	if err := h.repo.DeleteConversation(r.Context(), id); err != nil {
+		h.logger.Error().Err(err).Str("conversation_id", id).Msg("failed to delete conversation")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/crypto/bcrypt"
+
+	"chat-agent/backend/common/auth"
+	"chat-agent/backend/profile/internal/db"
+)
+
+// LoginHandler verifies agent credentials and issues access/refresh tokens.
+type LoginHandler struct {
+	repo            *db.Repository
+	issuer          *auth.Issuer
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	logger          zerolog.Logger
+}
+
+func NewLoginHandler(repo *db.Repository, issuer *auth.Issuer, accessTokenTTL, refreshTokenTTL time.Duration, logger zerolog.Logger) *LoginHandler {
+	return &LoginHandler{
+		repo:            repo,
+		issuer:          issuer,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+		logger:          logger,
+	}
+}
+
+type loginRequest struct {
+	AgentID  string `json:"agentId"`
+	Password string `json:"password"`
+}
+
+type loginResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+// HandleLogin verifies agentId/password against the bcrypt hash stored in
+// user_credentials and, on success, returns a short-lived access token
+// alongside a longer-lived refresh token.
+func (h *LoginHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if req.AgentID == "" || req.Password == "" {
+		http.Error(w, "agentId and password are required", http.StatusBadRequest)
+		return
+	}
+
+	cred, err := h.repo.GetUserCredential(r.Context(), req.AgentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+		h.logger.Error().Err(err).Str("agent_id", req.AgentID).Msg("failed to load credentials")
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(cred.PasswordHash), []byte(req.Password)); err != nil {
+		http.Error(w, "invalid credentials", http.StatusUnauthorized)
+		return
+	}
+
+	// user_credentials has no identity separate from the agent it logs in
+	// as, so userID and the sole entry in agentIDs are the same value.
+	accessToken, err := h.issuer.Issue(cred.AgentID, []string{cred.AgentID}, cred.Role, h.accessTokenTTL)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to issue access token")
+		http.Error(w, "failed to issue token", http.StatusInternalServerError)
+		return
+	}
+	refreshToken, err := h.issuer.Issue(cred.AgentID, []string{cred.AgentID}, cred.Role, h.refreshTokenTTL)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("failed to issue refresh token")
+		http.Error(w, "failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(loginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
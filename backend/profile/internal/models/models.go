@@ -3,11 +3,11 @@ package models
 import "time"
 
 type AgentProfile struct {
-	AgentID  string   `json:"agent_id"`
-	FirstName string  `json:"first_name"`
-	LastName  string  `json:"last_name"`
-	Agency    string  `json:"agency"`
-	Areas     []Area  `json:"areas"`
+	AgentID   string    `json:"agent_id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Agency    string    `json:"agency"`
+	Areas     []Area    `json:"areas"`
 	Listings  []Listing `json:"listings"`
 }
 
@@ -25,18 +25,34 @@ type Area struct {
 }
 
 type Listing struct {
-	Address   string     `json:"address"`
-	Suburb    string     `json:"suburb"`
-	Postcode  string     `json:"postcode"`
-	Status    string     `json:"status"`
+	Address    string     `json:"address"`
+	Suburb     string     `json:"suburb"`
+	Postcode   string     `json:"postcode"`
+	Status     string     `json:"status"`
 	UpdateDate *time.Time `json:"update_date,omitempty"`
 }
 
+// Conversation is a thread of messages exchanged with an agent's chat
+// assistant. Messages carry the query/answer pairs; the conversation row
+// tracks when the thread started and was last added to.
 type Conversation struct {
-	ID        int64     `json:"id"`
+	ID        string    `json:"id"`
 	AgentID   string    `json:"agent_id"`
-	Query     string    `json:"query"`
-	Response  string    `json:"response"`
-	CreatedAt time.Time `json:"created_at"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Messages  []Message `json:"messages,omitempty"`
 }
 
+// Message is a single query/answer pair within a Conversation. Embedding is
+// a vector of the query text, used to rank prior turns by semantic
+// similarity during memory retrieval; it's omitted from JSON responses
+// since callers only need the text.
+type Message struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	Query          string    `json:"query"`
+	Answer         string    `json:"answer"`
+	LatencyMS      int64     `json:"latency_ms"`
+	Embedding      []float32 `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -2,12 +2,10 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"syscall"
 	"time"
 
@@ -19,87 +17,92 @@ import (
 	"chat-agent/backend/search/internal/version"
 )
 
-var MockDB []search.ElasticDataRecord
+// newBackend selects the search.Backend implementation per cfg.SearchBackend:
+// "mock" runs against the in-memory InitElasticMockDB dataset, anything else
+// (including the default "elastic") talks to a real Elasticsearch cluster,
+// seeded from that same dataset on first boot if it comes up empty.
+func newBackend(cfg *config.Config, logger zerolog.Logger) (search.Backend, error) {
+	if cfg.SearchBackend == "mock" {
+		return search.NewMockBackend(InitElasticMockDB()), nil
+	}
 
-// LoadMockDB loads elastic-data.json into MockDB
-func LoadMockDB() ([]search.ElasticDataRecord, error) {
-	// Get the directory where the executable is located
-	execPath, err := os.Executable()
+	esClient, err := search.NewClient(cfg, logger.With().Str("component", "search").Logger())
 	if err != nil {
-		// Fallback to current working directory if we can't get executable path
-		cwd, _ := os.Getwd()
-		execPath = cwd
-	}
-	execDir := filepath.Dir(execPath)
-
-	// Try multiple possible paths
-	paths := []string{
-		// Same directory as executable (for compiled binary)
-		filepath.Join(execDir, "elastic-data.json"),
-		// Relative to executable directory (cmd/search/)
-		filepath.Join(execDir, "cmd", "search", "elastic-data.json"),
-		// Current working directory
-		"elastic-data.json",
-		filepath.Join(".", "elastic-data.json"),
-		// Relative to working directory
-		filepath.Join("cmd", "search", "elastic-data.json"),
-		filepath.Join("backend", "search", "cmd", "search", "elastic-data.json"),
+		return nil, err
 	}
+	return search.NewElasticBackend(esClient, InitElasticMockDB()), nil
+}
 
-	// Add paths relative to current working directory
-	if cwd, err := os.Getwd(); err == nil {
-		paths = append(paths,
-			filepath.Join(cwd, "elastic-data.json"),
-			filepath.Join(cwd, "cmd", "search", "elastic-data.json"),
-			filepath.Join(cwd, "backend", "search", "cmd", "search", "elastic-data.json"),
-		)
+// ingestFromPath streams path through LoadMockDBStream and ingests it in
+// fixed-size batches, so a dataset with hundreds of thousands of records
+// never needs to sit in memory as one giant slice the way the built-in mock
+// dataset does.
+func ingestFromPath(ctx context.Context, backend search.Backend, path string, logger zerolog.Logger) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
 	}
+	defer file.Close()
 
-	var data []search.ElasticDataRecord
-	var lastErr error
+	const batchSize = 500
+	records := make(chan search.ElasticDataRecord, batchSize)
+	streamErr := make(chan error, 1)
+	go func() {
+		defer close(records)
+		streamErr <- LoadMockDBStream(file, records)
+	}()
 
-	for _, path := range paths {
-		file, err := os.Open(path)
-		if err != nil {
-			lastErr = err
+	var total int
+	batch := make([]search.ElasticDataRecord, 0, batchSize)
+	for record := range records {
+		batch = append(batch, record)
+		if len(batch) < batchSize {
 			continue
 		}
-
-		decoder := json.NewDecoder(file)
-		if err := decoder.Decode(&data); err == nil {
-			file.Close()
-			return data, nil
+		if _, err := backend.IngestPeople(ctx, batch); err != nil {
+			return fmt.Errorf("failed to ingest batch: %w", err)
+		}
+		total += len(batch)
+		batch = batch[:0]
+	}
+	if len(batch) > 0 {
+		if _, err := backend.IngestPeople(ctx, batch); err != nil {
+			return fmt.Errorf("failed to ingest final batch: %w", err)
 		}
-		file.Close()
-		lastErr = err
+		total += len(batch)
 	}
 
-	return data, fmt.Errorf("failed to load elastic-data.json from any of the attempted paths: %w", lastErr)
+	if err := <-streamErr; err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	logger.Info().Int("records", total).Str("path", path).Msg("ingested ELASTIC_DATA_PATH dataset")
+	return nil
 }
 
 func main() {
 	logger := zerolog.New(os.Stdout).With().Timestamp().Str("version", version.BuildVersion).Logger()
 
-	// // Load mock data
-	// var err error
-	// MockDB, err = LoadMockDB()
-	// if err != nil {
-	// 	logger.Warn().Err(err).Msg("failed to load elastic-data.json, continuing without mock data")
-	// } else {
-	// 	logger.Info().Int("records", len(MockDB)).Msg("loaded mock data")
-	// 	// Set the mock data in the search package
-	// 	search.SetMockDB(MockDB)
-	// }
-
 	cfg := config.Load()
 
-	esClient, err := search.NewClient(cfg, logger.With().Str("component", "search").Logger())
-
+	backend, err := newBackend(cfg, logger)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("failed to init elasticsearch")
+		logger.Fatal().Err(err).Msg("failed to init search backend")
+	}
+
+	bootstrapCtx, cancelBootstrap := context.WithTimeout(context.Background(), 30*time.Second)
+	if err := backend.Bootstrap(bootstrapCtx); err != nil {
+		logger.Warn().Err(err).Msg("search backend bootstrap failed, continuing without it")
+	}
+	cancelBootstrap()
+
+	if cfg.ElasticDataPath != "" {
+		if err := ingestFromPath(context.Background(), backend, cfg.ElasticDataPath, logger); err != nil {
+			logger.Warn().Err(err).Str("path", cfg.ElasticDataPath).Msg("failed to ingest ELASTIC_DATA_PATH dataset")
+		}
 	}
 
-	server := api.NewServer(cfg, esClient, logger)
+	server := api.NewServer(cfg, backend, logger)
 
 	httpServer := &http.Server{
 		Addr:         cfg.ListenAddr,
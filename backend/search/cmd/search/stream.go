@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"chat-agent/backend/search/internal/search"
+)
+
+// LoadMockDBStream reads a dataset of ElasticDataRecord from r and sends
+// each one to out as it's decoded, so ingesting hundreds of thousands of
+// records doesn't require holding them all in memory at once the way
+// LoadMockDB's single json.Decode(&data) call does. It accepts either a
+// single JSON array (decoded element-by-element via Decoder.Token/Decode)
+// or newline-delimited JSON, and transparently gunzips input that starts
+// with the gzip magic bytes. out is not closed by this function -- the
+// caller owns that, typically from the goroutine that calls
+// LoadMockDBStream.
+func LoadMockDBStream(r io.Reader, out chan<- search.ElasticDataRecord) error {
+	br := bufio.NewReader(r)
+
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		br = bufio.NewReader(gz)
+	}
+
+	first, err := peekNonSpace(br)
+	if err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	if first == '[' {
+		return decodeJSONArray(br, out)
+	}
+	return decodeJSONL(br, out)
+}
+
+// peekNonSpace discards leading whitespace and returns the next byte
+// without consuming it, so the caller can sniff whether the input is a JSON
+// array or newline-delimited JSON.
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\n', '\r':
+			br.Discard(1)
+		default:
+			return b[0], nil
+		}
+	}
+}
+
+// decodeJSONArray streams a top-level JSON array of ElasticDataRecord,
+// decoding and sending one element at a time instead of Decode-ing the
+// whole array into a slice.
+func decodeJSONArray(r io.Reader, out chan<- search.ElasticDataRecord) error {
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read opening \"[\": %w", err)
+	}
+	for dec.More() {
+		var record search.ElasticDataRecord
+		if err := dec.Decode(&record); err != nil {
+			return fmt.Errorf("failed to decode record: %w", err)
+		}
+		out <- record
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read closing \"]\": %w", err)
+	}
+	return nil
+}
+
+// decodeJSONL reads one ElasticDataRecord per line, skipping blank lines.
+func decodeJSONL(r io.Reader, out chan<- search.ElasticDataRecord) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record search.ElasticDataRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return fmt.Errorf("failed to decode JSONL line: %w", err)
+		}
+		out <- record
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"chat-agent/backend/search/internal/search"
+)
+
+// benchRecordCount matches the "generated 1M-record file" the request asks
+// the two loaders to be compared against. Run with:
+//
+//	go test ./backend/search/cmd/search/ -bench=LoadMockDB -benchtime=1x
+const benchRecordCount = 1_000_000
+
+// generateMockDataFile writes a benchRecordCount-record JSON array to a temp
+// file once per benchmark and returns its path.
+func generateMockDataFile(tb testing.TB, n int) string {
+	tb.Helper()
+
+	path := filepath.Join(tb.TempDir(), "elastic-data.json")
+	f, err := os.Create(path)
+	if err != nil {
+		tb.Fatalf("failed to create %s: %v", path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	defer w.Flush()
+
+	w.WriteByte('[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		record := search.ElasticDataRecord{
+			ID: fmt.Sprintf("bench-%d", i),
+			Data: search.PersonData{
+				Name:         &search.PersonName{First: "Test", Last: fmt.Sprintf("Person%d", i)},
+				Address:      &search.PersonAddress{Suburb: "Bondi", State: "NSW", PostCode: "2026"},
+				LastSeenDate: "2024-01-01T00:00:00Z",
+			},
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			tb.Fatalf("failed to marshal record: %v", err)
+		}
+		w.Write(data)
+	}
+	w.WriteByte(']')
+
+	return path
+}
+
+// peakRSSKB reads the process's peak resident set size from /proc/self/status
+// (Linux-only; VmHWM is the high-water mark of RSS across the process's
+// lifetime). ok is false if the file or field isn't available, e.g. on a
+// non-Linux benchmark run.
+func peakRSSKB(tb testing.TB) (int64, bool) {
+	tb.Helper()
+
+	data, err := os.ReadFile("/proc/self/status")
+	if err != nil {
+		return 0, false
+	}
+	for _, line := range splitLines(data) {
+		if len(line) > 6 && string(line[:6]) == "VmHWM:" {
+			var kb int64
+			if _, err := fmt.Sscanf(string(line[6:]), "%d", &kb); err == nil {
+				return kb, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func splitLines(data []byte) [][]byte {
+	var lines [][]byte
+	start := 0
+	for i, b := range data {
+		if b == '\n' {
+			lines = append(lines, data[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(data) {
+		lines = append(lines, data[start:])
+	}
+	return lines
+}
+
+// BenchmarkLoadMockDB measures the current whole-array loader: a single
+// json.Decoder.Decode(&data) call that materializes every record into one
+// slice before the caller can do anything with it.
+func BenchmarkLoadMockDB(b *testing.B) {
+	path := generateMockDataFile(b, benchRecordCount)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		file, err := os.Open(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var data []search.ElasticDataRecord
+		if err := json.NewDecoder(file).Decode(&data); err != nil {
+			file.Close()
+			b.Fatal(err)
+		}
+		file.Close()
+		if len(data) != benchRecordCount {
+			b.Fatalf("got %d records, want %d", len(data), benchRecordCount)
+		}
+	}
+
+	if kb, ok := peakRSSKB(b); ok {
+		b.ReportMetric(float64(kb), "peak_rss_KB")
+	}
+}
+
+// BenchmarkLoadMockDBStream measures LoadMockDBStream used the way
+// ingestFromPath uses it: each record is consumed and discarded as it
+// arrives rather than accumulated into a single slice.
+func BenchmarkLoadMockDBStream(b *testing.B) {
+	path := generateMockDataFile(b, benchRecordCount)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		file, err := os.Open(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		out := make(chan search.ElasticDataRecord, 500)
+		streamErr := make(chan error, 1)
+		go func() {
+			defer close(out)
+			streamErr <- LoadMockDBStream(file, out)
+		}()
+
+		var count int
+		for range out {
+			count++
+		}
+		file.Close()
+
+		if err := <-streamErr; err != nil {
+			b.Fatal(err)
+		}
+		if count != benchRecordCount {
+			b.Fatalf("got %d records, want %d", count, benchRecordCount)
+		}
+	}
+
+	if kb, ok := peakRSSKB(b); ok {
+		b.ReportMetric(float64(kb), "peak_rss_KB")
+	}
+}
@@ -3,13 +3,17 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/rs/zerolog"
 
+	authmw "chat-agent/backend/common/auth"
 	"chat-agent/backend/search/internal/config"
 	"chat-agent/backend/search/internal/search"
 )
@@ -18,25 +22,153 @@ type Server struct {
 	router *chi.Mux
 }
 
-func NewServer(cfg *config.Config, client *search.Client, logger zerolog.Logger) *Server {
+// smartSearchRequest is the body for the structured POST /search/smart
+// route, which runs free-text q alongside area/date/geo filters against the
+// people index. The query-string GET variant runs the same filtered search
+// only when filters are given on the query string; otherwise it proxies the
+// external smart-search API as-is.
+type smartSearchRequest struct {
+	Query   string               `json:"q"`
+	Filters search.SearchFilters `json:"filters"`
+	Page    int                  `json:"page,omitempty"`
+	Size    int                  `json:"page_size,omitempty"`
+	Cursor  string               `json:"cursor,omitempty"`
+	// Fields is a comma-separated list of dotted PersonData paths (e.g.
+	// "name.first,address.suburb,mobile") that narrows each result down to
+	// that subset instead of returning the whole record. See
+	// search.Projector.
+	Fields string `json:"fields,omitempty"`
+}
+
+// pageRequestFromQuery reads page/page_size/cursor/fields query params into
+// a search.PageRequest, for the GET routes where filters aren't posted as
+// JSON.
+func pageRequestFromQuery(r *http.Request) search.PageRequest {
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	size, _ := strconv.Atoi(q.Get("page_size"))
+	return search.PageRequest{
+		Page:     page,
+		PageSize: size,
+		Cursor:   q.Get("cursor"),
+		Fields:   search.SplitFields(q.Get("fields")),
+	}
+}
+
+// searchFiltersFromQuery parses the same suburb/postCode/state/geo/date
+// filters the POST body's Filters field carries into a search.SearchFilters,
+// so GET /search/smart can narrow results the same way the POST route does.
+// ok reports whether any filter was actually supplied, so the GET handler
+// knows whether to run the filtered people search or fall back to its
+// existing free-text-only behavior.
+func searchFiltersFromQuery(r *http.Request) (search.SearchFilters, bool) {
+	q := r.URL.Query()
+	var filters search.SearchFilters
+	var ok bool
+
+	if v := q.Get("suburb"); v != "" {
+		filters.Suburb = v
+		ok = true
+	}
+	if v := q.Get("postCode"); v != "" {
+		filters.PostCode = v
+		ok = true
+	}
+	if v := q.Get("state"); v != "" {
+		filters.State = v
+		ok = true
+	}
+	if v := q.Get("lastSeenFrom"); v != "" {
+		filters.LastSeenFrom = v
+		ok = true
+	}
+	if v := q.Get("lastSeenTo"); v != "" {
+		filters.LastSeenTo = v
+		ok = true
+	}
+	if v := q.Get("geoLat"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			filters.GeoLat = f
+			ok = true
+		}
+	}
+	if v := q.Get("geoLon"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			filters.GeoLon = f
+			ok = true
+		}
+	}
+	if v := q.Get("geoDistanceKm"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			filters.GeoDistanceKM = f
+			ok = true
+		}
+	}
+	return filters, ok
+}
+
+func NewServer(cfg *config.Config, backend search.Backend, logger zerolog.Logger) *Server {
 	r := chi.NewRouter()
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
+	if verifier, ok := newVerifier(cfg, logger); ok {
+		r.Use(verifier.Authenticate)
+	}
+
 	r.Get("/search/people", func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query().Get("q")
-		results, err := client.SearchPeople(r.Context(), query)
+		page, err := backend.SearchPeople(r.Context(), query, search.SearchFilters{}, pageRequestFromQuery(r))
 		if err != nil {
-			logger.Error().Err(err).Msg("people search failed")
-			http.Error(w, "search error", http.StatusBadGateway)
+			writeSearchError(w, logger, "people search failed", err)
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	r.Post("/admin/ingest/people", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		records, err := search.DecodeRecords(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, err := backend.IngestPeople(r.Context(), records)
+		if err != nil {
+			logger.Error().Err(err).Msg("people ingest failed")
+			http.Error(w, "ingest error", http.StatusBadGateway)
 			return
 		}
-		json.NewEncoder(w).Encode(results)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
 	})
 
 	r.Get("/search/smart", func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query().Get("q")
+
+		// If any structured filter was given on the query string, this is
+		// the same filtered people search POST /search/smart runs, just
+		// addressed via GET -- the external SmartSearch API below has no
+		// notion of suburb/postcode/geo filters to forward them to.
+		if filters, ok := searchFiltersFromQuery(r); ok {
+			page, err := backend.SearchPeople(r.Context(), query, filters, pageRequestFromQuery(r))
+			if err != nil {
+				writeSearchError(w, logger, "filtered smart search failed", err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(page)
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 		defer cancel()
 		results, err := search.SmartSearch(ctx, query, cfg.SmartSearchSize, logger)
@@ -58,9 +190,55 @@ func NewServer(cfg *config.Config, client *search.Client, logger zerolog.Logger)
 		}
 	})
 
+	r.Post("/search/smart", func(w http.ResponseWriter, r *http.Request) {
+		var req smartSearchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		pageReq := search.PageRequest{Page: req.Page, PageSize: req.Size, Cursor: req.Cursor, Fields: search.SplitFields(req.Fields)}
+		page, err := backend.SearchPeople(r.Context(), req.Query, req.Filters, pageReq)
+		if err != nil {
+			writeSearchError(w, logger, "filtered smart search failed", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	})
+
 	return &Server{router: r}
 }
 
+// writeSearchError maps a SearchPeople error to a response: an invalid or
+// expired cursor is the caller's fault (400), anything else is treated as a
+// backend failure (502).
+func writeSearchError(w http.ResponseWriter, logger zerolog.Logger, msg string, err error) {
+	if errors.Is(err, search.ErrInvalidCursor) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	logger.Error().Err(err).Msg(msg)
+	http.Error(w, "search error", http.StatusBadGateway)
+}
+
 func (s *Server) Router() *chi.Mux {
 	return s.router
 }
+
+// newVerifier builds the JWT verifier for this service's routes. Auth is
+// left disabled when no secret or public key is configured, so local
+// development without AUTH_JWT_SECRET keeps working unauthenticated.
+func newVerifier(cfg *config.Config, logger zerolog.Logger) (*authmw.Verifier, bool) {
+	if cfg.JWTSecret == "" && cfg.JWKSURL == "" && cfg.JWTPubKeyPath == "" {
+		logger.Warn().Msg("AUTH_JWT_SECRET/AUTH_JWKS_URL/AUTH_JWT_PUBKEY_PATH not set, running without authentication")
+		return nil, false
+	}
+
+	verifier, err := authmw.NewVerifier(cfg.JWTSecret, cfg.JWKSURL, cfg.JWTPubKeyPath)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to initialise JWT verifier")
+	}
+	return verifier, true
+}
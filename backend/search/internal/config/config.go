@@ -11,6 +11,11 @@ type Config struct {
 	IndexPeople     string
 	IndexProperty   string
 	SmartSearchSize int
+	JWTSecret       string
+	JWKSURL         string
+	JWTPubKeyPath   string
+	SearchBackend   string
+	ElasticDataPath string
 }
 
 func Load() *Config {
@@ -20,6 +25,20 @@ func Load() *Config {
 		IndexPeople:     envOr("ES_INDEX_PEOPLE", "people"),
 		IndexProperty:   envOr("ES_INDEX_PROPERTY", "properties"),
 		SmartSearchSize: envIntOr("SMART_SEARCH_SIZE", 15),
+		JWTSecret:       envOr("AUTH_JWT_SECRET", ""),
+		JWKSURL:         envOr("AUTH_JWKS_URL", ""),
+		JWTPubKeyPath:   envOr("AUTH_JWT_PUBKEY_PATH", ""),
+		// SearchBackend selects the search.Backend implementation: "elastic"
+		// (default) hits a real Elasticsearch cluster, "mock" runs against an
+		// in-memory dataset for local development without one.
+		SearchBackend: envOr("SEARCH_BACKEND", "elastic"),
+		// ElasticDataPath, when set, names a JSON array or newline-delimited
+		// JSON file (optionally gzip-compressed) of ElasticDataRecord that
+		// is ingested at startup on top of the small built-in mock dataset,
+		// which still seeds the backend as before. It's read via
+		// LoadMockDBStream so a large file isn't held in memory as one
+		// decoded slice.
+		ElasticDataPath: envOr("ELASTIC_DATA_PATH", ""),
 	}
 }
 
@@ -0,0 +1,108 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Backend is the pluggable search implementation used by the HTTP layer, so
+// the API handlers don't need to know whether requests are served by a real
+// Elasticsearch cluster or an in-memory dataset for local development.
+type Backend interface {
+	// Bootstrap prepares the backend for traffic: creating/mapping the
+	// people index and seeding it if empty for ElasticBackend, a no-op for
+	// MockBackend.
+	Bootstrap(ctx context.Context) error
+	IngestPeople(ctx context.Context, records []ElasticDataRecord) (*IngestResult, error)
+	SearchPeople(ctx context.Context, query string, filters SearchFilters, page PageRequest) (*SearchResultPage, error)
+}
+
+// SearchResultPage is one page of formatted SearchPeople results, alongside
+// the pagination metadata a client needs to fetch the next one.
+type SearchResultPage struct {
+	Results    []map[string]interface{} `json:"results"`
+	Total      int                      `json:"total"`
+	NextCursor string                   `json:"nextCursor,omitempty"`
+	TookMS     int64                    `json:"tookMs"`
+}
+
+// ElasticBackend implements Backend against a real Elasticsearch cluster via
+// Client, bootstrapping the index with seed data the first time it runs
+// against an empty cluster.
+type ElasticBackend struct {
+	client *Client
+	seed   []ElasticDataRecord
+}
+
+// NewElasticBackend wraps client as a Backend, seeding the people index with
+// seed on Bootstrap if it comes up empty.
+func NewElasticBackend(client *Client, seed []ElasticDataRecord) *ElasticBackend {
+	return &ElasticBackend{client: client, seed: seed}
+}
+
+// Bootstrap ensures the people index exists with the nested address mapping,
+// then seeds it from the configured mock dataset if it's empty -- so a fresh
+// Elasticsearch cluster has something to search against without a separate
+// manual ingest step.
+func (b *ElasticBackend) Bootstrap(ctx context.Context) error {
+	if err := b.client.ensureIndex(ctx); err != nil {
+		return fmt.Errorf("failed to ensure people index: %w", err)
+	}
+
+	count, err := b.client.countPeople(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count people index: %w", err)
+	}
+	if count > 0 {
+		b.client.logger.Info().Int64("count", count).Msg("people index already seeded")
+		return nil
+	}
+
+	if len(b.seed) == 0 {
+		return nil
+	}
+
+	result, err := b.client.SeedPeople(ctx, b.seed)
+	if err != nil {
+		return fmt.Errorf("failed to seed people index: %w", err)
+	}
+	b.client.logger.Info().Int("indexed", result.Indexed).Int("failed", result.Failed).Msg("seeded people index from mock data")
+	return nil
+}
+
+func (b *ElasticBackend) IngestPeople(ctx context.Context, records []ElasticDataRecord) (*IngestResult, error) {
+	return b.client.IngestPeople(ctx, records)
+}
+
+// SearchPeople delegates to Client.SearchPeopleFiltered, mapping page into a
+// plain from/size offset. Cursor-based iteration (search_after on the sort
+// key Paginator uses) isn't implemented against Elasticsearch yet -- Total
+// and an offset-based page are still returned, but NextCursor is always
+// empty until that follow-up lands.
+func (b *ElasticBackend) SearchPeople(ctx context.Context, query string, filters SearchFilters, page PageRequest) (*SearchResultPage, error) {
+	start := time.Now()
+
+	pageSize := page.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	pageNum := page.Page
+	if pageNum < 1 {
+		pageNum = 1
+	}
+
+	result, total, err := b.client.searchPeoplePage(ctx, query, filters, (pageNum-1)*pageSize, pageSize, page.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SearchResultPage{
+		Results: formatResult(result),
+		Total:   total,
+		TookMS:  time.Since(start).Milliseconds(),
+	}, nil
+}
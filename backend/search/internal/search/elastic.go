@@ -5,8 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"time"
 
 	es "github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/rs/zerolog"
 
 	"chat-agent/backend/search/internal/config"
@@ -35,16 +38,27 @@ type PersonName struct {
 
 // PersonAddress represents the address object
 type PersonAddress struct {
-	StreetNumber string `json:"street-number,omitempty"`
-	StreetName   string `json:"street-name,omitempty"`
-	Suburb       string `json:"suburb,omitempty"`
-	State        string `json:"state,omitempty"`
-	PostCode     string `json:"post-code,omitempty"`
+	StreetNumber string    `json:"street-number,omitempty"`
+	StreetName   string    `json:"street-name,omitempty"`
+	Suburb       string    `json:"suburb,omitempty"`
+	State        string    `json:"state,omitempty"`
+	PostCode     string    `json:"post-code,omitempty"`
+	Location     *GeoPoint `json:"location,omitempty"`
+}
+
+// GeoPoint is a lat/lng pair, mapped to Elasticsearch's geo_point type so
+// geo_distance filters can run against it.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
 }
 
 // SearchResult represents the result structure from Elasticsearch
 type SearchResult struct {
 	Hits struct {
+		Total struct {
+			Value int `json:"value"`
+		} `json:"total"`
 		Hits []struct {
 			ID     string                 `json:"_id"`
 			Source map[string]interface{} `json:"_source"`
@@ -59,10 +73,17 @@ type Client struct {
 	logger  zerolog.Logger
 }
 
-// NewClient creates a new Elasticsearch client
+// NewClient creates a new Elasticsearch client. A request is retried with
+// exponential backoff when Elasticsearch answers the whole request with 429
+// (rejection under load) or 502/503/504. A bulk request that succeeds
+// overall but reports 429/5xx for individual items is not retried; bulkIndex
+// counts those items as failed.
 func NewClient(cfg *config.Config, logger zerolog.Logger) (*Client, error) {
 	client, err := es.NewClient(es.Config{
-		Addresses: []string{cfg.ESAddress},
+		Addresses:     []string{cfg.ESAddress},
+		RetryOnStatus: []int{http.StatusTooManyRequests, 502, 503, 504},
+		MaxRetries:    5,
+		RetryBackoff:  retryBackoff,
 	})
 	if err != nil {
 		return nil, err
@@ -74,27 +95,140 @@ func NewClient(cfg *config.Config, logger zerolog.Logger) (*Client, error) {
 	}, nil
 }
 
+// retryBackoff doubles the delay on each attempt (100ms, 200ms, 400ms, ...),
+// capped at 5s so a long MaxRetries run doesn't end up waiting minutes.
+func retryBackoff(attempt int) time.Duration {
+	backoff := 100 * time.Millisecond * time.Duration(1<<uint(attempt-1))
+	if cap := 5 * time.Second; backoff > cap {
+		backoff = cap
+	}
+	return backoff
+}
+
+// SearchFilters narrows a search to an agent's area and other structured
+// criteria, in addition to the free-text query. There is no price filter:
+// this client only ever queries the people index (see searchPeoplePage),
+// and PersonData has no price field to range against.
+type SearchFilters struct {
+	Suburb        string  `json:"suburb,omitempty"`
+	PostCode      string  `json:"postCode,omitempty"`
+	State         string  `json:"state,omitempty"`
+	LastSeenFrom  string  `json:"lastSeenFrom,omitempty"`
+	LastSeenTo    string  `json:"lastSeenTo,omitempty"`
+	GeoLat        float64 `json:"geoLat,omitempty"`
+	GeoLon        float64 `json:"geoLon,omitempty"`
+	GeoDistanceKM float64 `json:"geoDistanceKm,omitempty"`
+
+	// Name, when set, switches the mock backend over to fuzzy/phonetic
+	// relevance ranking via RecordScorer instead of the plain free-text
+	// query. Not yet honored by ElasticBackend.
+	Name *NameQuery `json:"name,omitempty"`
+}
+
+// hasGeo reports whether enough fields were supplied to build a geo_distance
+// filter.
+func (f SearchFilters) hasGeo() bool {
+	return f.GeoDistanceKM > 0 && (f.GeoLat != 0 || f.GeoLon != 0)
+}
+
 // Search performs a search query against Elasticsearch
 func (c *Client) SearchElastic(ctx context.Context, index, query string) (*SearchResult, error) {
-	body := map[string]any{
-		"query": map[string]any{
-			"multi_match": map[string]any{
-				"query":  query,
-				"fields": []string{"name^3", "description", "address", "suburb"},
-			},
+	return c.SearchElasticFiltered(ctx, index, query, SearchFilters{})
+}
+
+// SearchElasticFiltered is SearchElastic plus structured filters, compiled
+// into a bool query: multi_match on the free-text query, combined with term,
+// range, and geo_distance filter clauses for whichever fields were set.
+func (c *Client) SearchElasticFiltered(ctx context.Context, index, query string, filters SearchFilters) (*SearchResult, error) {
+	return c.searchElasticPage(ctx, index, query, filters, 0, 10, nil)
+}
+
+// searchElasticPage is SearchElasticFiltered with an explicit from/size
+// offset and field list, used by searchPeoplePage to paginate and project.
+// track_total_hits is set so Hits.Total.Value reflects the full match count
+// rather than the default 10,000-hit cap. fields, if non-empty, is sent as
+// _source_includes (prefixed "data." via Projector.SourceIncludes) so
+// Elasticsearch narrows each hit's _source before it's even returned,
+// rather than this process fetching the whole document and discarding most
+// of it.
+func (c *Client) searchElasticPage(ctx context.Context, index, query string, filters SearchFilters, from, size int, fields []string) (*SearchResult, error) {
+	must := map[string]any{
+		"multi_match": map[string]any{
+			"query":  query,
+			"fields": []string{"name^3", "description", "address", "suburb"},
 		},
-		"size": 10,
+	}
+
+	var filterClauses []map[string]any
+
+	var addressMust []map[string]any
+	if filters.Suburb != "" {
+		addressMust = append(addressMust, map[string]any{"term": map[string]any{"data.address.suburb": filters.Suburb}})
+	}
+	if filters.PostCode != "" {
+		addressMust = append(addressMust, map[string]any{"term": map[string]any{"data.address.post-code": filters.PostCode}})
+	}
+	if filters.State != "" {
+		addressMust = append(addressMust, map[string]any{"term": map[string]any{"data.address.state": filters.State}})
+	}
+	if filters.hasGeo() {
+		addressMust = append(addressMust, map[string]any{
+			"geo_distance": map[string]any{
+				"distance": fmt.Sprintf("%gkm", filters.GeoDistanceKM),
+				"data.address.location": map[string]any{
+					"lat": filters.GeoLat,
+					"lon": filters.GeoLon,
+				},
+			},
+		})
+	}
+	// data.address is mapped as a nested type (see ensurePeopleMapping), so
+	// every clause touching it has to live inside a single nested query
+	// rather than as flat term/geo_distance filters.
+	if len(addressMust) > 0 {
+		filterClauses = append(filterClauses, map[string]any{
+			"nested": map[string]any{
+				"path":  "data.address",
+				"query": map[string]any{"bool": map[string]any{"must": addressMust}},
+			},
+		})
+	}
+
+	if filters.LastSeenFrom != "" || filters.LastSeenTo != "" {
+		dateRange := map[string]any{}
+		if filters.LastSeenFrom != "" {
+			dateRange["gte"] = filters.LastSeenFrom
+		}
+		if filters.LastSeenTo != "" {
+			dateRange["lte"] = filters.LastSeenTo
+		}
+		filterClauses = append(filterClauses, map[string]any{"range": map[string]any{"data.last-seen-date": dateRange}})
+	}
+	boolQuery := map[string]any{"must": must}
+	if len(filterClauses) > 0 {
+		boolQuery["filter"] = filterClauses
+	}
+
+	body := map[string]any{
+		"query":            map[string]any{"bool": boolQuery},
+		"from":             from,
+		"size":             size,
+		"track_total_hits": true,
 	}
 	payload, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := c.elastic.Search(
+	searchOpts := []func(*esapi.SearchRequest){
 		c.elastic.Search.WithContext(ctx),
 		c.elastic.Search.WithIndex(index),
 		c.elastic.Search.WithBody(bytes.NewReader(payload)),
-	)
+	}
+	if includes := NewProjector(fields).SourceIncludes(); len(includes) > 0 {
+		searchOpts = append(searchOpts, c.elastic.Search.WithSourceIncludes(includes...))
+	}
+	res, err := c.elastic.Search(searchOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -129,9 +263,27 @@ func formatResult(result *SearchResult) []map[string]interface{} {
 
 // SearchPeople searches for people in Elasticsearch
 func (c *Client) SearchPeople(ctx context.Context, query string) ([]map[string]interface{}, error) {
-	result, err := c.SearchElastic(ctx, c.cfg.IndexPeople, query)
+	return c.SearchPeopleFiltered(ctx, query, SearchFilters{})
+}
+
+// SearchPeopleFiltered searches for people in Elasticsearch, narrowed by the
+// given structured filters (suburb, postcode, state, last-seen-date range,
+// geo-distance from a lat/lng).
+func (c *Client) SearchPeopleFiltered(ctx context.Context, query string, filters SearchFilters) ([]map[string]interface{}, error) {
+	result, err := c.SearchElasticFiltered(ctx, c.cfg.IndexPeople, query, filters)
 	if err != nil {
 		return nil, err
 	}
 	return formatResult(result), nil
 }
+
+// searchPeoplePage is SearchPeopleFiltered with an explicit from/size
+// offset and field list, also returning the total hit count for
+// ElasticBackend.SearchPeople.
+func (c *Client) searchPeoplePage(ctx context.Context, query string, filters SearchFilters, from, size int, fields []string) (*SearchResult, int, error) {
+	result, err := c.searchElasticPage(ctx, c.cfg.IndexPeople, query, filters, from, size, fields)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, result.Hits.Total.Value, nil
+}
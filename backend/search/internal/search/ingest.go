@@ -0,0 +1,312 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"github.com/google/uuid"
+)
+
+// IngestResult summarises the outcome of a bulk ingest run.
+type IngestResult struct {
+	Indexed int `json:"indexed"`
+	Updated int `json:"updated"`
+	Failed  int `json:"failed"`
+}
+
+// recordDocID resolves the deterministic Elasticsearch _id for a record: its
+// own ID if it has one, otherwise a UUIDv5 derived from mobile+email. Basing
+// the fallback on that pair rather than generating a random ID means
+// re-ingesting the same feed twice -- with the same ID-less rows -- still
+// upserts the same documents instead of duplicating them.
+func recordDocID(record ElasticDataRecord) string {
+	if record.ID != "" {
+		return record.ID
+	}
+	name := record.Data.Mobile + "|" + record.Data.Email
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
+
+// ensurePeopleMapping declares the index fields that need a non-default
+// Elasticsearch type to be queryable: address as nested (so the suburb,
+// post-code, state, and location sub-fields of a single address can be
+// queried together via a nested query, see SearchElasticFiltered),
+// address.location as geo_point (for geo_distance filters), and
+// last-seen-date as date (for range filters). It's safe to call repeatedly —
+// Elasticsearch no-ops a mapping update that matches what's already there.
+func (c *Client) ensurePeopleMapping(ctx context.Context) error {
+	payload, err := json.Marshal(peopleMapping())
+	if err != nil {
+		return fmt.Errorf("failed to marshal mapping: %w", err)
+	}
+
+	res, err := c.elastic.Indices.PutMapping(
+		[]string{c.cfg.IndexPeople},
+		bytes.NewReader(payload),
+		c.elastic.Indices.PutMapping.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply mapping: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("mapping update error: %s", res.String())
+	}
+	return nil
+}
+
+// peopleMapping is the people index's field mapping: data.address is nested
+// (each address's suburb/post-code/state/location sub-fields must be matched
+// against the *same* address, not any address across the document), with
+// location further typed geo_point and the textual sub-fields keyword so
+// they support exact term matches.
+func peopleMapping() map[string]any {
+	return map[string]any{
+		"properties": map[string]any{
+			"data": map[string]any{
+				"properties": map[string]any{
+					"address": map[string]any{
+						"type": "nested",
+						"properties": map[string]any{
+							"suburb":    map[string]any{"type": "keyword"},
+							"state":     map[string]any{"type": "keyword"},
+							"post-code": map[string]any{"type": "keyword"},
+							"location":  map[string]any{"type": "geo_point"},
+						},
+					},
+					"last-seen-date": map[string]any{"type": "date"},
+				},
+			},
+		},
+	}
+}
+
+// ensureIndex makes sure cfg.IndexPeople resolves to something before any
+// ingest or search runs against it: if it doesn't exist yet, a fresh
+// timestamped index is created and aliased to it (see newIndexName/
+// swapAlias), otherwise the mapping is patched onto whatever it already
+// resolves to via ensurePeopleMapping. It never touches documents -- a full
+// reseed goes through SeedPeople, which is what actually swaps the alias to
+// a newly bulk-loaded index.
+func (c *Client) ensureIndex(ctx context.Context) error {
+	existsRes, err := c.elastic.Indices.Exists(
+		[]string{c.cfg.IndexPeople},
+		c.elastic.Indices.Exists.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to check index existence: %w", err)
+	}
+	defer existsRes.Body.Close()
+
+	if existsRes.StatusCode == 404 {
+		_, err := c.createAndSwap(ctx, c.newIndexName(), nil)
+		return err
+	}
+
+	return c.ensurePeopleMapping(ctx)
+}
+
+// newIndexName generates the concrete, timestamped index name a reindex
+// writes into, e.g. "people-v20240102150405".
+func (c *Client) newIndexName() string {
+	return fmt.Sprintf("%s-v%s", c.cfg.IndexPeople, time.Now().Format("20060102150405"))
+}
+
+// createAndSwap creates a fresh mapped index, bulk-loads records into it (if
+// any), and atomically repoints the cfg.IndexPeople alias at it -- so a
+// reindex is visible to searches all at once, never as a half-populated
+// index mid-load.
+func (c *Client) createAndSwap(ctx context.Context, index string, records []ElasticDataRecord) (*IngestResult, error) {
+	payload, err := json.Marshal(map[string]any{"mappings": peopleMapping()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal index mapping: %w", err)
+	}
+
+	createRes, err := c.elastic.Indices.Create(
+		index,
+		c.elastic.Indices.Create.WithContext(ctx),
+		c.elastic.Indices.Create.WithBody(bytes.NewReader(payload)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create index %s: %w", index, err)
+	}
+	defer createRes.Body.Close()
+	if createRes.IsError() {
+		return nil, fmt.Errorf("index creation error: %s", createRes.String())
+	}
+
+	result, err := c.bulkIndex(ctx, index, records)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.swapAlias(ctx, index); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// swapAlias atomically repoints the cfg.IndexPeople alias at index, removing
+// it from any other "<cfg.IndexPeople>-v*" index it currently points to.
+// Bundling the remove and add into one Indices.UpdateAliases call is what
+// makes the swap atomic -- there's no instant where the alias resolves to
+// zero or two indices.
+func (c *Client) swapAlias(ctx context.Context, index string) error {
+	body := map[string]any{
+		"actions": []map[string]any{
+			{"remove": map[string]any{"index": c.cfg.IndexPeople + "-v*", "alias": c.cfg.IndexPeople}},
+			{"add": map[string]any{"index": index, "alias": c.cfg.IndexPeople}},
+		},
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alias swap: %w", err)
+	}
+
+	res, err := c.elastic.Indices.UpdateAliases(
+		bytes.NewReader(payload),
+		c.elastic.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to swap people alias: %w", err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("alias swap error: %s", res.String())
+	}
+	return nil
+}
+
+// countPeople returns the number of documents currently in the people index,
+// used to decide whether a fresh index needs seeding.
+func (c *Client) countPeople(ctx context.Context) (int64, error) {
+	res, err := c.elastic.Count(
+		c.elastic.Count.WithContext(ctx),
+		c.elastic.Count.WithIndex(c.cfg.IndexPeople),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count people index: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("count error: %s", res.String())
+	}
+
+	var parsed struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		return 0, fmt.Errorf("failed to decode count response: %w", err)
+	}
+	return parsed.Count, nil
+}
+
+// IngestPeople bulk-upserts records into the live people index (the
+// cfg.IndexPeople alias) for incremental updates -- a single feed batch or
+// the /admin/ingest/people route -- where a full reindex+alias-swap would be
+// overkill. See bulkIndex for the indexing details and SeedPeople for the
+// reindex path used to reload the whole dataset without a half-populated
+// window.
+func (c *Client) IngestPeople(ctx context.Context, records []ElasticDataRecord) (*IngestResult, error) {
+	if err := c.ensurePeopleMapping(ctx); err != nil {
+		return nil, fmt.Errorf("mapping migration failed: %w", err)
+	}
+	return c.bulkIndex(ctx, c.cfg.IndexPeople, records)
+}
+
+// SeedPeople replaces the entire people dataset: records are bulk-loaded
+// into a brand new timestamped index, which only then becomes "people" via
+// an atomic alias swap (see createAndSwap/swapAlias). Unlike IngestPeople,
+// searches against the people alias never observe a partially-loaded
+// reindex, since the alias keeps pointing at the old, fully-populated index
+// until the new one is completely ready.
+func (c *Client) SeedPeople(ctx context.Context, records []ElasticDataRecord) (*IngestResult, error) {
+	return c.createAndSwap(ctx, c.newIndexName(), records)
+}
+
+// bulkIndex upserts records into index via the Bulk API, using
+// recordDocID as each document's deterministic _id so re-ingesting the same
+// record -- including the UUIDv5 fallback for an ID-less row -- overwrites
+// the prior document instead of duplicating it. The underlying client
+// retries a whole bulk request with backoff when it gets a 429/502/503/504
+// response (see NewClient); items the bulk response reports as failed are
+// not retried and are counted in Failed. esutil.BulkIndexer's default 5MB
+// flush threshold keeps each request chunk-sized rather than shipping the
+// whole batch in one go.
+func (c *Client) bulkIndex(ctx context.Context, index string, records []ElasticDataRecord) (*IngestResult, error) {
+	indexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Index:  index,
+		Client: c.elastic,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk indexer: %w", err)
+	}
+
+	var indexed, updated, failed int64
+
+	for _, record := range records {
+		id := recordDocID(record)
+
+		payload, err := json.Marshal(record)
+		if err != nil {
+			atomic.AddInt64(&failed, 1)
+			c.logger.Warn().Err(err).Str("id", id).Msg("failed to marshal record for ingest")
+			continue
+		}
+
+		err = indexer.Add(ctx, esutil.BulkIndexerItem{
+			Action:     "index",
+			DocumentID: id,
+			Body:       bytes.NewReader(payload),
+			OnSuccess: func(ctx context.Context, item esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem) {
+				if res.Result == "updated" {
+					atomic.AddInt64(&updated, 1)
+				} else {
+					atomic.AddInt64(&indexed, 1)
+				}
+			},
+			OnFailure: func(ctx context.Context, item esutil.BulkIndexerItem, res esutil.BulkIndexerResponseItem, err error) {
+				atomic.AddInt64(&failed, 1)
+				c.logger.Error().Err(err).Str("id", item.DocumentID).Str("reason", res.Error.Reason).Msg("bulk ingest item failed")
+			},
+		})
+		if err != nil {
+			atomic.AddInt64(&failed, 1)
+			c.logger.Error().Err(err).Str("id", id).Msg("failed to queue record for ingest")
+		}
+	}
+
+	if err := indexer.Close(ctx); err != nil {
+		return nil, fmt.Errorf("bulk indexer close failed: %w", err)
+	}
+
+	stats := indexer.Stats()
+	c.logger.Info().
+		Str("index", index).
+		Uint64("num_flushed", stats.NumFlushed).
+		Uint64("num_failed", stats.NumFailed).
+		Msg("people ingest complete")
+
+	return &IngestResult{
+		Indexed: int(indexed),
+		Updated: int(updated),
+		Failed:  int(failed),
+	}, nil
+}
+
+// DecodeRecords parses a JSON array of ElasticDataRecord, such as the
+// contents of elastic-data.json, into ingest-ready records.
+func DecodeRecords(body []byte) ([]ElasticDataRecord, error) {
+	var records []ElasticDataRecord
+	if err := json.Unmarshal(body, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode records: %w", err)
+	}
+	return records, nil
+}
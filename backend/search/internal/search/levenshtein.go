@@ -0,0 +1,81 @@
+package search
+
+// boundedLevenshtein computes the Levenshtein edit distance between a and b,
+// but gives up as soon as it can prove the distance exceeds maxDist --
+// classic banded dynamic programming, so a long, wildly different pair of
+// strings doesn't cost O(len(a)*len(b)) for a comparison the caller was
+// always going to reject.
+func boundedLevenshtein(a, b string, maxDist int) (int, bool) {
+	ar := []rune(a)
+	br := []rune(b)
+
+	if abs(len(ar)-len(br)) > maxDist {
+		return 0, false
+	}
+
+	const inf = 1 << 30
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		cur := make([]int, len(br)+1)
+		cur[0] = i
+
+		lo := i - maxDist
+		if lo < 1 {
+			lo = 1
+		}
+		hi := i + maxDist
+		if hi > len(br) {
+			hi = len(br)
+		}
+		for j := 1; j < lo; j++ {
+			cur[j] = inf
+		}
+
+		rowMin := cur[0]
+		for j := lo; j <= hi; j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			del := prev[j] + 1
+			ins := cur[j-1] + 1
+			sub := prev[j-1] + cost
+
+			best := del
+			if ins < best {
+				best = ins
+			}
+			if sub < best {
+				best = sub
+			}
+			cur[j] = best
+			if best < rowMin {
+				rowMin = best
+			}
+		}
+		for j := hi + 1; j <= len(br); j++ {
+			cur[j] = inf
+		}
+
+		if rowMin > maxDist {
+			return 0, false
+		}
+		prev = cur
+	}
+
+	if prev[len(br)] > maxDist {
+		return 0, false
+	}
+	return prev[len(br)], true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,108 @@
+package search
+
+import "strings"
+
+// MatchMode selects how Matcher compares a query value against a candidate
+// field value.
+type MatchMode string
+
+const (
+	// MatchExact requires a case-insensitive exact match. It's the default
+	// when a field's mode isn't specified.
+	MatchExact MatchMode = "exact"
+	// MatchPrefix matches candidates that start with the query, e.g. a
+	// lastName filter narrowing to everyone whose surname starts with "Tho".
+	MatchPrefix MatchMode = "prefix"
+	// MatchFuzzy allows up to Matcher.MaxEditDistance character edits
+	// (insertions, deletions, substitutions), for typos like "Micheal" vs
+	// "Michael".
+	MatchFuzzy MatchMode = "fuzzy"
+	// MatchPhonetic matches candidates that sound alike via Double
+	// Metaphone, for spelling variants like "Catherine" vs "Katherine".
+	MatchPhonetic MatchMode = "phonetic"
+)
+
+// DefaultMaxEditDistance caps the fuzzy strategy's tolerance -- enough to
+// catch a dropped/transposed/substituted letter or two without matching
+// unrelated names of similar length.
+const DefaultMaxEditDistance = 2
+
+// Matcher scores how well a candidate field value matches a query value
+// under a given MatchMode. The zero value is usable; NewMatcher just makes
+// the default edit-distance cap explicit.
+type Matcher struct {
+	// MaxEditDistance bounds MatchFuzzy. Zero or negative falls back to
+	// DefaultMaxEditDistance.
+	MaxEditDistance int
+}
+
+// NewMatcher returns a Matcher configured with DefaultMaxEditDistance.
+func NewMatcher() *Matcher {
+	return &Matcher{MaxEditDistance: DefaultMaxEditDistance}
+}
+
+// Score returns a match score in [0, 1], where 0 means "no match" and 1
+// means the strongest match that mode can report.
+func (m *Matcher) Score(mode MatchMode, query, candidate string) float64 {
+	if query == "" || candidate == "" {
+		return 0
+	}
+	switch mode {
+	case MatchPrefix:
+		return m.scorePrefix(query, candidate)
+	case MatchFuzzy:
+		return m.scoreFuzzy(query, candidate)
+	case MatchPhonetic:
+		return m.scorePhonetic(query, candidate)
+	default:
+		return m.scoreExact(query, candidate)
+	}
+}
+
+func (m *Matcher) scoreExact(query, candidate string) float64 {
+	if strings.EqualFold(query, candidate) {
+		return 1
+	}
+	return 0
+}
+
+func (m *Matcher) scorePrefix(query, candidate string) float64 {
+	if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(query)) {
+		return 1
+	}
+	return 0
+}
+
+func (m *Matcher) scoreFuzzy(query, candidate string) float64 {
+	maxDist := m.MaxEditDistance
+	if maxDist <= 0 {
+		maxDist = DefaultMaxEditDistance
+	}
+
+	dist, ok := boundedLevenshtein(strings.ToLower(query), strings.ToLower(candidate), maxDist)
+	if !ok {
+		return 0
+	}
+
+	longest := len([]rune(query))
+	if c := len([]rune(candidate)); c > longest {
+		longest = c
+	}
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(dist)/float64(longest)
+}
+
+func (m *Matcher) scorePhonetic(query, candidate string) float64 {
+	qPrimary, qSecondary := doubleMetaphone(query)
+	cPrimary, cSecondary := doubleMetaphone(candidate)
+	if qPrimary == "" || cPrimary == "" {
+		return 0
+	}
+	if qPrimary == cPrimary || qPrimary == cSecondary || qSecondary == cPrimary ||
+		(qSecondary != "" && qSecondary == cSecondary) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package search
+
+import "testing"
+
+func TestMatcherFuzzyCatchesTypos(t *testing.T) {
+	m := NewMatcher()
+
+	cases := []struct{ query, candidate string }{
+		{"Micheal", "Michael"},
+		{"Thomson", "Thompson"},
+	}
+	for _, c := range cases {
+		if score := m.Score(MatchFuzzy, c.query, c.candidate); score <= 0 {
+			t.Errorf("Score(fuzzy, %q, %q) = %v, want > 0", c.query, c.candidate, score)
+		}
+	}
+}
+
+func TestMatcherFuzzyRejectsBeyondEditDistance(t *testing.T) {
+	m := &Matcher{MaxEditDistance: 2}
+	if score := m.Score(MatchFuzzy, "Smith", "Anderson"); score != 0 {
+		t.Errorf("Score(fuzzy, Smith, Anderson) = %v, want 0", score)
+	}
+}
+
+func TestMatcherPrefix(t *testing.T) {
+	m := NewMatcher()
+	if score := m.Score(MatchPrefix, "Tho", "Thompson"); score != 1 {
+		t.Errorf("Score(prefix, Tho, Thompson) = %v, want 1", score)
+	}
+	if score := m.Score(MatchPrefix, "son", "Thompson"); score != 0 {
+		t.Errorf("Score(prefix, son, Thompson) = %v, want 0", score)
+	}
+}
+
+func TestMatcherPhoneticMatchesSoundAlikes(t *testing.T) {
+	m := NewMatcher()
+	if score := m.Score(MatchPhonetic, "Catherine", "Katherine"); score != 1 {
+		t.Errorf("Score(phonetic, Catherine, Katherine) = %v, want 1", score)
+	}
+}
+
+func TestRecordScorerWeightsLastNameHighest(t *testing.T) {
+	scorer := NewRecordScorer(NewMatcher())
+	record := ElasticDataRecord{
+		ID: "person-001",
+		Data: PersonData{
+			Name:    &PersonName{First: "Michael", Last: "Thompson"},
+			Address: &PersonAddress{Suburb: "Bondi"},
+		},
+	}
+
+	lastNameOnly := NameQuery{LastName: FieldQuery{Value: "Thomson", Mode: MatchFuzzy}}
+	firstNameOnly := NameQuery{FirstName: FieldQuery{Value: "Micheal", Mode: MatchFuzzy}}
+
+	lastScore := scorer.Score(record, lastNameOnly)
+	firstScore := scorer.Score(record, firstNameOnly)
+	if lastScore <= 0 || firstScore <= 0 {
+		t.Fatalf("expected both fields to score > 0, got last=%v first=%v", lastScore, firstScore)
+	}
+
+	// With one name field matching and the other not, a last-name match has
+	// to outrank a first-name match.
+	lastMatches := NameQuery{
+		LastName:  FieldQuery{Value: "Thomson", Mode: MatchFuzzy},
+		FirstName: FieldQuery{Value: "Zzzzzz", Mode: MatchFuzzy},
+	}
+	firstMatches := NameQuery{
+		LastName:  FieldQuery{Value: "Zzzzzz", Mode: MatchFuzzy},
+		FirstName: FieldQuery{Value: "Micheal", Mode: MatchFuzzy},
+	}
+	if l, f := scorer.Score(record, lastMatches), scorer.Score(record, firstMatches); l <= f {
+		t.Errorf("last-name-only match scored %v, want > first-name-only match %v", l, f)
+	}
+
+	combined := NameQuery{
+		LastName:  FieldQuery{Value: "Thomson", Mode: MatchFuzzy},
+		FirstName: FieldQuery{Value: "Micheal", Mode: MatchFuzzy},
+	}
+	if score := scorer.Score(record, combined); score < DefaultMinScore {
+		t.Errorf("Score() = %v, want >= DefaultMinScore for a full typo'd name match", score)
+	}
+}
@@ -0,0 +1,189 @@
+package search
+
+import "strings"
+
+// doubleMetaphone encodes a word into primary and secondary phonetic keys,
+// so names that are spelled differently but pronounced alike (e.g.
+// "Smith"/"Smyth", "Catherine"/"Katherine") compare equal under
+// MatchPhonetic. This implements the common English letter-sound rules of
+// the Double Metaphone algorithm (silent letters, digraphs, soft/hard C and
+// G) rather than its full original rule set, which also covers Germanic,
+// Slavic, and other non-English spelling conventions. secondary is only set
+// where a sound genuinely has two common readings (e.g. a soft "C"); it
+// otherwise matches primary.
+func doubleMetaphone(word string) (primary, secondary string) {
+	word = strings.ToUpper(word)
+	var letters []rune
+	for _, r := range word {
+		if r >= 'A' && r <= 'Z' {
+			letters = append(letters, r)
+		}
+	}
+	if len(letters) == 0 {
+		return "", ""
+	}
+
+	at := func(i int) rune {
+		if i < 0 || i >= len(letters) {
+			return 0
+		}
+		return letters[i]
+	}
+	isVowel := func(r rune) bool {
+		switch r {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			return true
+		}
+		return false
+	}
+
+	var p, s strings.Builder
+	add := func(code rune) {
+		p.WriteRune(code)
+		s.WriteRune(code)
+	}
+	addAlt := func(primaryCode, secondaryCode rune) {
+		p.WriteRune(primaryCode)
+		s.WriteRune(secondaryCode)
+	}
+
+	i := 0
+	// Initial letter combinations with a silent first letter.
+	switch {
+	case strings.HasPrefix(word, "GN"), strings.HasPrefix(word, "KN"), strings.HasPrefix(word, "PN"), strings.HasPrefix(word, "WR"):
+		i = 1
+	case strings.HasPrefix(word, "X"):
+		add('S')
+		i = 1
+	case strings.HasPrefix(word, "WH"):
+		add('W')
+		i = 2
+	}
+
+	const maxLen = 4
+	for ; i < len(letters) && p.Len() < maxLen; i++ {
+		c := letters[i]
+		if c == at(i-1) && c != 'C' {
+			continue // collapse doubled letters, except CC which is handled below
+		}
+
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if i == 0 {
+				add(c)
+			}
+		case 'B':
+			if !(c == 'B' && at(i+1) == 0 && at(i-1) == 'M') {
+				add('B')
+			}
+		case 'C':
+			switch {
+			case at(i+1) == 'I' && at(i+2) == 'A':
+				add('X')
+			case at(i+1) == 'H':
+				if at(i-1) == 'S' {
+					add('K')
+				} else {
+					add('X')
+				}
+				i++
+			case isVowel(at(i+1)) && (at(i+1) == 'I' || at(i+1) == 'E' || at(i+1) == 'Y'):
+				addAlt('S', 'K')
+			default:
+				add('K')
+			}
+		case 'D':
+			if at(i+1) == 'G' && (at(i+2) == 'I' || at(i+2) == 'E' || at(i+2) == 'Y') {
+				add('J')
+				i += 2
+			} else {
+				add('T')
+			}
+		case 'F':
+			add('F')
+		case 'G':
+			switch {
+			case at(i+1) == 'N' && i+2 >= len(letters):
+				// silent, nothing to add
+			case at(i+1) == 'H':
+				if !isVowel(at(i + 2)) {
+					// silent GH
+				} else {
+					add('F')
+				}
+				i++
+			case at(i+1) == 'I' || at(i+1) == 'E' || at(i+1) == 'Y':
+				addAlt('J', 'K')
+			default:
+				add('K')
+			}
+		case 'H':
+			if isVowel(at(i-1)) && isVowel(at(i+1)) {
+				add('H')
+			}
+		case 'J':
+			add('J')
+		case 'K':
+			if at(i-1) != 'C' {
+				add('K')
+			}
+		case 'L':
+			add('L')
+		case 'M':
+			add('M')
+		case 'N':
+			add('N')
+		case 'P':
+			if at(i+1) == 'H' {
+				add('F')
+				i++
+			} else {
+				add('P')
+			}
+		case 'Q':
+			add('K')
+		case 'R':
+			add('R')
+		case 'S':
+			switch {
+			case at(i+1) == 'H':
+				add('X')
+				i++
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				add('X')
+			default:
+				add('S')
+			}
+		case 'T':
+			switch {
+			case at(i+1) == 'I' && (at(i+2) == 'O' || at(i+2) == 'A'):
+				add('X')
+			case at(i+1) == 'H':
+				add('T')
+				i++
+			default:
+				add('T')
+			}
+		case 'V':
+			add('F')
+		case 'W':
+			if isVowel(at(i + 1)) {
+				add('W')
+			}
+		case 'X':
+			add('K')
+			add('S')
+		case 'Z':
+			add('S')
+		}
+	}
+
+	return truncate(p.String(), maxLen), truncate(s.String(), maxLen)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
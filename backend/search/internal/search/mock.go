@@ -4,15 +4,200 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 )
 
-// mockDB holds the loaded elastic-data.json records (set via SetMockDB)
-var mockDB []ElasticDataRecord
+// MockBackend implements Backend against an in-memory slice of records, for
+// local development without a running Elasticsearch cluster.
+type MockBackend struct {
+	records []ElasticDataRecord
+}
+
+// NewMockBackend seeds a MockBackend with the given records up front --
+// there's no separate bootstrap step to load them from, unlike ElasticBackend.
+func NewMockBackend(records []ElasticDataRecord) *MockBackend {
+	return &MockBackend{records: records}
+}
+
+// Bootstrap is a no-op: the mock dataset is already in memory from
+// NewMockBackend, with no index or mapping to create.
+func (b *MockBackend) Bootstrap(ctx context.Context) error {
+	return nil
+}
+
+func (b *MockBackend) IngestPeople(ctx context.Context, records []ElasticDataRecord) (*IngestResult, error) {
+	b.records = append(b.records, records...)
+	return &IngestResult{Indexed: len(records)}, nil
+}
+
+// SearchPeople scans the in-memory records for a free-text match plus the
+// structured filters, then paginates the matches with a Paginator. It only
+// supports the suburb/post-code/state filters -- PersonData has no price
+// field, and geo/date comparisons aren't worth reimplementing for a dataset
+// that only exists for local development.
+//
+// If filters.Name is set, this takes the fuzzy/phonetic relevance-ranking
+// path instead: see searchByName. Callers that only ever send free text --
+// like the chat agent's search_people tool, which has no way to populate
+// filters.Name -- still reach that path as a fallback when the exact
+// substring match finds nothing: see nameQueryFromFreeText.
+func (b *MockBackend) SearchPeople(ctx context.Context, query string, filters SearchFilters, page PageRequest) (*SearchResultPage, error) {
+	start := time.Now()
+
+	if filters.Name != nil && !filters.Name.isZero() {
+		return b.searchByName(*filters.Name, page, start)
+	}
+
+	var matches []ElasticDataRecord
+	for _, record := range b.records {
+		if query != "" && !matchesQuery(record, query) {
+			continue
+		}
+		if !matchesFilters(record, filters) {
+			continue
+		}
+		matches = append(matches, record)
+	}
+
+	if len(matches) == 0 {
+		if nameQuery, ok := nameQueryFromFreeText(query); ok {
+			return b.searchByName(nameQuery, page, start)
+		}
+	}
+
+	paged, err := NewPaginator(matches).Page(page)
+	if err != nil {
+		return nil, err
+	}
+
+	projector := NewProjector(page.Fields)
+	results := make([]map[string]interface{}, len(paged.Records))
+	for i, record := range paged.Records {
+		results[i] = projector.ProjectRecord(recordToMap(record))
+	}
+
+	return &SearchResultPage{
+		Results:    results,
+		Total:      paged.Total,
+		NextCursor: paged.NextCursor,
+		TookMS:     time.Since(start).Milliseconds(),
+	}, nil
+}
 
-// SetMockDB sets the mock database for testing/searching
-func SetMockDB(data []ElasticDataRecord) {
-	mockDB = data
+// scoredRecord pairs a record with its RecordScorer relevance score, used
+// only while sorting in searchByName.
+type scoredRecord struct {
+	record ElasticDataRecord
+	score  float64
+}
+
+// searchByName ranks records by relevance against a NameQuery, keeping only
+// those at or above its score threshold. Because the result order is by
+// relevance rather than the LastSeenDate/ID order Paginator assumes,
+// pagination here is a plain page/page_size offset -- cursor-based
+// iteration isn't supported for relevance-ranked queries.
+func (b *MockBackend) searchByName(q NameQuery, page PageRequest, start time.Time) (*SearchResultPage, error) {
+	threshold := q.MinScore
+	if threshold <= 0 {
+		threshold = DefaultMinScore
+	}
+
+	scorer := NewRecordScorer(NewMatcher())
+	var matches []scoredRecord
+	for _, record := range b.records {
+		if score := scorer.Score(record, q); score >= threshold {
+			matches = append(matches, scoredRecord{record: record, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].record.ID < matches[j].record.ID
+	})
+
+	pageSize := page.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	pageNum := page.Page
+	if pageNum < 1 {
+		pageNum = 1
+	}
+	from := (pageNum - 1) * pageSize
+	to := from + pageSize
+	if from > len(matches) {
+		from = len(matches)
+	}
+	if to > len(matches) {
+		to = len(matches)
+	}
+
+	projector := NewProjector(page.Fields)
+	results := make([]map[string]interface{}, 0, to-from)
+	for _, m := range matches[from:to] {
+		item := projector.ProjectRecord(recordToMap(m.record))
+		item["score"] = m.score
+		results = append(results, item)
+	}
+
+	return &SearchResultPage{
+		Results: results,
+		Total:   len(matches),
+		TookMS:  time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// nameQueryFromFreeText turns free-text search terms into a fuzzy NameQuery,
+// so a misspelled or misheard name typed into the plain query string (e.g.
+// "Micheal Thomson") still reaches the fuzzy/phonetic scoring searchByName
+// uses, rather than only being reachable via an explicit filters.Name. A
+// single word is tried against both name fields since it's unclear which
+// the caller meant; a multi-word query treats the first word as a first
+// name and the rest as a last name.
+func nameQueryFromFreeText(query string) (NameQuery, bool) {
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return NameQuery{}, false
+	}
+	if len(words) == 1 {
+		return NameQuery{
+			FirstName: FieldQuery{Value: words[0], Mode: MatchFuzzy},
+			LastName:  FieldQuery{Value: words[0], Mode: MatchFuzzy},
+		}, true
+	}
+	return NameQuery{
+		FirstName: FieldQuery{Value: words[0], Mode: MatchFuzzy},
+		LastName:  FieldQuery{Value: strings.Join(words[1:], " "), Mode: MatchFuzzy},
+	}, true
+}
+
+// matchesFilters checks the suburb/post-code/state filters against a
+// record's address, case-insensitively. Any filter field left empty is
+// ignored.
+func matchesFilters(record ElasticDataRecord, filters SearchFilters) bool {
+	address := record.Data.Address
+	if filters.Suburb != "" {
+		if address == nil || !strings.EqualFold(address.Suburb, filters.Suburb) {
+			return false
+		}
+	}
+	if filters.PostCode != "" {
+		if address == nil || !strings.EqualFold(address.PostCode, filters.PostCode) {
+			return false
+		}
+	}
+	if filters.State != "" {
+		if address == nil || !strings.EqualFold(address.State, filters.State) {
+			return false
+		}
+	}
+	return true
 }
 
 // searchInValue recursively searches for a query string in any value (case-insensitive)
@@ -58,33 +243,3 @@ func matchesQuery(record ElasticDataRecord, query string) bool {
 	recordMap := recordToMap(record)
 	return searchInValue(recordMap, query)
 }
-
-// SearchMockDB searches through the mock database
-func SearchMockDB(ctx context.Context, index, query string) (*SearchResult, error) {
-	if query == "" {
-		return &SearchResult{}, nil
-	}
-
-	// Search through mockDB records
-	var matches []ElasticDataRecord
-	for _, record := range mockDB {
-		if matchesQuery(record, query) {
-			matches = append(matches, record)
-		}
-	}
-
-	// Convert matches to SearchResult format
-	result := &SearchResult{}
-	for _, record := range matches {
-		hit := struct {
-			ID     string                 `json:"_id"`
-			Source map[string]interface{} `json:"_source"`
-		}{
-			ID:     record.ID,
-			Source: recordToMap(record),
-		}
-		result.Hits.Hits = append(result.Hits.Hits, hit)
-	}
-
-	return result, nil
-}
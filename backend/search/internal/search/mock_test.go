@@ -0,0 +1,42 @@
+package search
+
+import "testing"
+
+func TestSearchPeopleFreeTextFallsBackToFuzzyName(t *testing.T) {
+	backend := NewMockBackend([]ElasticDataRecord{
+		{
+			ID: "person-001",
+			Data: PersonData{
+				Name:    &PersonName{First: "Michael", Last: "Thompson"},
+				Address: &PersonAddress{Suburb: "Bondi"},
+			},
+		},
+	})
+
+	page, err := backend.SearchPeople(nil, "Micheal Thomson", SearchFilters{}, PageRequest{})
+	if err != nil {
+		t.Fatalf("SearchPeople() error = %v", err)
+	}
+	if len(page.Results) != 1 {
+		t.Fatalf("SearchPeople() returned %d results, want 1", len(page.Results))
+	}
+}
+
+func TestSearchPeopleExactMatchSkipsFuzzyFallback(t *testing.T) {
+	backend := NewMockBackend([]ElasticDataRecord{
+		{ID: "person-001", Data: PersonData{Name: &PersonName{First: "Michael", Last: "Thompson"}}},
+		{ID: "person-002", Data: PersonData{Name: &PersonName{First: "Someone", Last: "Else"}, Address: &PersonAddress{Suburb: "Thomson"}}},
+	})
+
+	page, err := backend.SearchPeople(nil, "Thomson", SearchFilters{}, PageRequest{})
+	if err != nil {
+		t.Fatalf("SearchPeople() error = %v", err)
+	}
+	if len(page.Results) != 1 {
+		t.Fatalf("SearchPeople() returned %d results, want 1 (the exact substring match)", len(page.Results))
+	}
+	// The fuzzy fallback would have returned person-001 instead.
+	if id := page.Results[0]["id"]; id != "person-002" {
+		t.Errorf("SearchPeople() returned %v, want person-002 (the exact substring match)", id)
+	}
+}
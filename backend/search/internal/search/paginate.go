@@ -0,0 +1,174 @@
+package search
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// DefaultPageSize and MaxPageSize bound PageRequest.PageSize: zero or
+// negative falls back to the default, anything above the max is clamped.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ErrInvalidCursor is returned when a cursor token fails to decode, or
+// decodes to a sort key that's no longer present in the dataset (e.g. the
+// underlying record was deleted since the cursor was issued).
+var ErrInvalidCursor = errors.New("invalid or expired cursor")
+
+// cursorKey is the sort-key tuple of the last record on a page, encoded as
+// the next page's cursor. Encoding the key rather than a raw offset means a
+// cursor still resolves correctly if records are inserted or removed between
+// calls, as long as the key's record itself still exists.
+type cursorKey struct {
+	LastSeenDate string `json:"lastSeenDate"`
+	ID           string `json:"id"`
+}
+
+// PageRequest describes one page of a paginated search: PageSize and either
+// Page (an absolute 1-based page number, for jumping directly to a page) or
+// Cursor (an opaque token from a previous Page.NextCursor, for stable
+// iteration). Cursor takes precedence when both are set. Fields, if set,
+// narrows each result down to that subset of PersonData paths (see
+// Projector) instead of returning the whole record.
+type PageRequest struct {
+	Page     int
+	PageSize int
+	Cursor   string
+	Fields   []string
+}
+
+// Page is one page of paginated results, plus enough metadata to fetch the
+// next one and to render a total count.
+type Page struct {
+	Records    []ElasticDataRecord
+	Total      int
+	NextCursor string
+	TookMS     int64
+}
+
+// Paginator produces deterministic, cursor-based pages over a fixed set of
+// records. Records are sorted once, by LastSeenDate desc then ID asc, so
+// iteration order stays stable across calls even if the caller's underlying
+// index or query order shifts between them.
+type Paginator struct {
+	sorted []ElasticDataRecord
+}
+
+// NewPaginator copies and sorts records for pagination. The copy means the
+// caller's slice can keep changing without affecting a Paginator already
+// handed out.
+func NewPaginator(records []ElasticDataRecord) *Paginator {
+	sorted := make([]ElasticDataRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Data.LastSeenDate != sorted[j].Data.LastSeenDate {
+			return sorted[i].Data.LastSeenDate > sorted[j].Data.LastSeenDate
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return &Paginator{sorted: sorted}
+}
+
+// Page returns the page of records described by req.
+func (p *Paginator) Page(req PageRequest) (*Page, error) {
+	start := time.Now()
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	startIdx, err := p.startIndex(req, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	endIdx := startIdx + pageSize
+	if endIdx > len(p.sorted) {
+		endIdx = len(p.sorted)
+	}
+	if startIdx > endIdx {
+		startIdx = endIdx
+	}
+
+	records := make([]ElasticDataRecord, endIdx-startIdx)
+	copy(records, p.sorted[startIdx:endIdx])
+
+	var nextCursor string
+	if endIdx < len(p.sorted) {
+		last := records[len(records)-1]
+		nextCursor, err = encodeCursor(cursorKey{LastSeenDate: last.Data.LastSeenDate, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Page{
+		Records:    records,
+		Total:      len(p.sorted),
+		NextCursor: nextCursor,
+		TookMS:     time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// startIndex resolves req to an index into p.sorted: from the decoded cursor
+// if one was given, otherwise from the absolute page number (1-based,
+// defaulting to 1).
+func (p *Paginator) startIndex(req PageRequest, pageSize int) (int, error) {
+	if req.Cursor != "" {
+		key, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return 0, ErrInvalidCursor
+		}
+		idx := p.indexAfter(key)
+		if idx == -1 {
+			return 0, ErrInvalidCursor
+		}
+		return idx, nil
+	}
+
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+	return (page - 1) * pageSize, nil
+}
+
+// indexAfter returns the index immediately following the record matching
+// key, or -1 if no record in the sorted set matches it.
+func (p *Paginator) indexAfter(key cursorKey) int {
+	for i, r := range p.sorted {
+		if r.Data.LastSeenDate == key.LastSeenDate && r.ID == key.ID {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func encodeCursor(key cursorKey) (string, error) {
+	raw, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
+
+func decodeCursor(cursor string) (cursorKey, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return cursorKey{}, err
+	}
+	var key cursorKey
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return cursorKey{}, err
+	}
+	return key, nil
+}
@@ -0,0 +1,141 @@
+package search
+
+import "testing"
+
+func paginatorFixture() []ElasticDataRecord {
+	return []ElasticDataRecord{
+		{ID: "p1", Data: PersonData{LastSeenDate: "2024-01-01T00:00:00Z"}},
+		{ID: "p2", Data: PersonData{LastSeenDate: "2024-01-03T00:00:00Z"}},
+		{ID: "p3", Data: PersonData{LastSeenDate: "2024-01-02T00:00:00Z"}},
+		{ID: "p4", Data: PersonData{LastSeenDate: "2024-01-03T00:00:00Z"}},
+		{ID: "p5", Data: PersonData{LastSeenDate: "2024-01-02T00:00:00Z"}},
+	}
+}
+
+func recordIDs(records []ElasticDataRecord) []string {
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestPaginatorFirstPage(t *testing.T) {
+	p := NewPaginator(paginatorFixture())
+
+	page, err := p.Page(PageRequest{PageSize: 2})
+	if err != nil {
+		t.Fatalf("Page() error = %v", err)
+	}
+	if page.Total != 5 {
+		t.Errorf("Total = %d, want 5", page.Total)
+	}
+	// Sorted by LastSeenDate desc, then ID asc: p2/p4 share the latest date.
+	if got, want := recordIDs(page.Records), []string{"p2", "p4"}; !equalIDs(got, want) {
+		t.Errorf("Records = %v, want %v", got, want)
+	}
+	if page.NextCursor == "" {
+		t.Error("NextCursor = \"\", want non-empty (more pages remain)")
+	}
+}
+
+func TestPaginatorCursorIteration(t *testing.T) {
+	p := NewPaginator(paginatorFixture())
+
+	var all []string
+	req := PageRequest{PageSize: 2}
+	for {
+		page, err := p.Page(req)
+		if err != nil {
+			t.Fatalf("Page() error = %v", err)
+		}
+		all = append(all, recordIDs(page.Records)...)
+		if page.NextCursor == "" {
+			break
+		}
+		req = PageRequest{PageSize: 2, Cursor: page.NextCursor}
+	}
+
+	want := []string{"p2", "p4", "p3", "p5", "p1"}
+	if !equalIDs(all, want) {
+		t.Errorf("iterated ids = %v, want %v", all, want)
+	}
+}
+
+func TestPaginatorLastPagePartial(t *testing.T) {
+	p := NewPaginator(paginatorFixture())
+
+	page, err := p.Page(PageRequest{Page: 3, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Page() error = %v", err)
+	}
+	if got, want := recordIDs(page.Records), []string{"p1"}; !equalIDs(got, want) {
+		t.Errorf("Records = %v, want %v", got, want)
+	}
+	if page.NextCursor != "" {
+		t.Errorf("NextCursor = %q, want empty (no more pages)", page.NextCursor)
+	}
+}
+
+func TestPaginatorEmptyPageBeyondEnd(t *testing.T) {
+	p := NewPaginator(paginatorFixture())
+
+	page, err := p.Page(PageRequest{Page: 10, PageSize: 2})
+	if err != nil {
+		t.Fatalf("Page() error = %v", err)
+	}
+	if len(page.Records) != 0 {
+		t.Errorf("len(Records) = %d, want 0", len(page.Records))
+	}
+	if page.NextCursor != "" {
+		t.Errorf("NextCursor = %q, want empty", page.NextCursor)
+	}
+	if page.Total != 5 {
+		t.Errorf("Total = %d, want 5", page.Total)
+	}
+}
+
+func TestPaginatorEmptyDataset(t *testing.T) {
+	p := NewPaginator(nil)
+
+	page, err := p.Page(PageRequest{PageSize: 10})
+	if err != nil {
+		t.Fatalf("Page() error = %v", err)
+	}
+	if len(page.Records) != 0 || page.Total != 0 || page.NextCursor != "" {
+		t.Errorf("Page() = %+v, want empty page", page)
+	}
+}
+
+func TestPaginatorInvalidCursor(t *testing.T) {
+	p := NewPaginator(paginatorFixture())
+
+	if _, err := p.Page(PageRequest{PageSize: 2, Cursor: "not-valid-base64!!"}); err != ErrInvalidCursor {
+		t.Errorf("Page() error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestPaginatorExpiredCursor(t *testing.T) {
+	p := NewPaginator(paginatorFixture())
+
+	cursor, err := encodeCursor(cursorKey{LastSeenDate: "2024-01-03T00:00:00Z", ID: "p999"})
+	if err != nil {
+		t.Fatalf("encodeCursor() error = %v", err)
+	}
+
+	if _, err := p.Page(PageRequest{PageSize: 2, Cursor: cursor}); err != ErrInvalidCursor {
+		t.Errorf("Page() error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package search
+
+import "strings"
+
+// Projector narrows a record's "data" fields down to a requested subset, so
+// a caller that only needs e.g. a name and suburb for a result card doesn't
+// get the whole PersonData payload back. Fields are given as comma-separated
+// dotted paths relative to "data" (e.g. "name.first,address.suburb,mobile")
+// -- the same paths as PersonData's JSON tags -- so the same field list
+// drives both MockBackend's in-process projection (ProjectRecord) and, for
+// ElasticBackend, Elasticsearch's _source_includes filter (SourceIncludes),
+// which does the equivalent job on the Elasticsearch side before a document
+// ever reaches this process.
+type Projector struct {
+	root *projectorNode
+}
+
+// projectorNode is one level of the path tree built from the requested
+// fields. A leaf (no children) means "keep everything under this key".
+type projectorNode struct {
+	children map[string]*projectorNode
+}
+
+// NewProjector parses fields into a Projector. An empty (or all-blank)
+// fields yields a no-op Projector whose ProjectRecord and SourceIncludes
+// leave their input untouched, the same as if no projection was requested.
+func NewProjector(fields []string) *Projector {
+	root := &projectorNode{children: map[string]*projectorNode{}}
+	for _, field := range fields {
+		node := root
+		for _, part := range strings.Split(field, ".") {
+			if part == "" {
+				continue
+			}
+			child, ok := node.children[part]
+			if !ok {
+				child = &projectorNode{children: map[string]*projectorNode{}}
+				node.children[part] = child
+			}
+			node = child
+		}
+	}
+	return &Projector{root: root}
+}
+
+// SplitFields parses a comma-separated fields parameter (e.g.
+// "name.first,address.suburb,mobile") into the dotted-path slice NewProjector
+// expects, dropping blank entries left by stray commas or whitespace.
+func SplitFields(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	var fields []string
+	for _, part := range strings.Split(raw, ",") {
+		if field := strings.TrimSpace(part); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Empty reports whether no fields were requested, i.e. ProjectRecord and
+// SourceIncludes are no-ops.
+func (p *Projector) Empty() bool {
+	return p == nil || len(p.root.children) == 0
+}
+
+// ProjectRecord narrows item's "data" key down to the requested fields,
+// leaving every other top-level key (id, score, ...) untouched. item is
+// typically the map produced by recordToMap. It returns item unchanged if
+// no fields were requested.
+func (p *Projector) ProjectRecord(item map[string]interface{}) map[string]interface{} {
+	if p.Empty() {
+		return item
+	}
+	out := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		if k == "data" {
+			if data, ok := v.(map[string]interface{}); ok {
+				v = projectValue(data, p.root)
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// projectValue walks value down node, keeping only the keys node's children
+// name. A node with no children is a leaf: its whole subtree is kept as-is.
+func projectValue(value interface{}, node *projectorNode) interface{} {
+	if len(node.children) == 0 {
+		return value
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	out := make(map[string]interface{}, len(node.children))
+	for key, child := range node.children {
+		if v, exists := m[key]; exists {
+			out[key] = projectValue(v, child)
+		}
+	}
+	return out
+}
+
+// SourceIncludes returns the requested fields prefixed with "data.", the
+// form Elasticsearch's _source_includes expects since every indexed
+// document wraps its fields in a top-level "data" object (see
+// ElasticDataRecord). It returns nil if no fields were requested, meaning
+// "include everything".
+func (p *Projector) SourceIncludes() []string {
+	if p.Empty() {
+		return nil
+	}
+	return collectPaths("data", p.root)
+}
+
+func collectPaths(prefix string, node *projectorNode) []string {
+	var paths []string
+	for key, child := range node.children {
+		path := prefix + "." + key
+		if len(child.children) == 0 {
+			paths = append(paths, path)
+			continue
+		}
+		paths = append(paths, collectPaths(path, child)...)
+	}
+	return paths
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func sampleRecordMap(t *testing.T) map[string]interface{} {
+	t.Helper()
+
+	record := ElasticDataRecord{
+		ID: "person-001",
+		Data: PersonData{
+			Name:    &PersonName{First: "Jane", Last: "Doe"},
+			Address: &PersonAddress{Suburb: "Bondi", State: "NSW"},
+			Mobile:  "0400000000",
+			Email:   "jane@example.com",
+		},
+	}
+	raw, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("failed to marshal record: %v", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		t.Fatalf("failed to unmarshal record: %v", err)
+	}
+	return m
+}
+
+func TestProjectorNarrowsToRequestedFields(t *testing.T) {
+	p := NewProjector([]string{"name.first", "address.suburb", "mobile"})
+	got := p.ProjectRecord(sampleRecordMap(t))
+
+	want := map[string]interface{}{
+		"id": "person-001",
+		"data": map[string]interface{}{
+			"name":    map[string]interface{}{"first": "Jane"},
+			"address": map[string]interface{}{"suburb": "Bondi"},
+			"mobile":  "0400000000",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProjectRecord() = %#v, want %#v", got, want)
+	}
+}
+
+func TestProjectorEmptyIsNoOp(t *testing.T) {
+	p := NewProjector(nil)
+	if !p.Empty() {
+		t.Fatal("expected Empty() for no fields")
+	}
+
+	record := sampleRecordMap(t)
+	if got := p.ProjectRecord(record); !reflect.DeepEqual(got, record) {
+		t.Errorf("ProjectRecord() = %#v, want unchanged %#v", got, record)
+	}
+	if includes := p.SourceIncludes(); includes != nil {
+		t.Errorf("SourceIncludes() = %v, want nil", includes)
+	}
+}
+
+func TestProjectorSourceIncludesPrefixesData(t *testing.T) {
+	p := NewProjector([]string{"name.first", "mobile"})
+	includes := p.SourceIncludes()
+
+	want := map[string]bool{"data.name.first": true, "data.mobile": true}
+	if len(includes) != len(want) {
+		t.Fatalf("SourceIncludes() = %v, want 2 entries matching %v", includes, want)
+	}
+	for _, path := range includes {
+		if !want[path] {
+			t.Errorf("unexpected SourceIncludes() entry %q", path)
+		}
+	}
+}
+
+func TestSplitFieldsDropsBlankEntries(t *testing.T) {
+	got := SplitFields(" name.first ,, address.suburb,")
+	want := []string{"name.first", "address.suburb"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitFields() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+package search
+
+// FieldQuery is one field's half of a NameQuery: the value to match and the
+// strategy to match it with.
+type FieldQuery struct {
+	Value string    `json:"value,omitempty"`
+	Mode  MatchMode `json:"mode,omitempty"`
+}
+
+// NameQuery is a fuzzy/phonetic people search: each field is matched
+// independently against its MatchMode, then combined into a single
+// relevance score by RecordScorer.
+type NameQuery struct {
+	LastName  FieldQuery `json:"lastName,omitempty"`
+	FirstName FieldQuery `json:"firstName,omitempty"`
+	Suburb    FieldQuery `json:"suburb,omitempty"`
+	Street    FieldQuery `json:"street,omitempty"`
+	// MinScore is the relevance threshold a record must meet to be
+	// returned. Zero falls back to DefaultMinScore.
+	MinScore float64 `json:"minScore,omitempty"`
+}
+
+func (q NameQuery) isZero() bool {
+	return q.LastName.Value == "" && q.FirstName.Value == "" && q.Suburb.Value == "" && q.Street.Value == ""
+}
+
+// DefaultMinScore is the relevance cutoff applied when a NameQuery doesn't
+// set its own MinScore.
+const DefaultMinScore = 0.5
+
+// Field weights for RecordScorer: a last name match is the strongest signal
+// a record is the one being searched for, then first name, then the two
+// address fields.
+const (
+	weightLastName  = 0.4
+	weightFirstName = 0.3
+	weightSuburb    = 0.2
+	weightStreet    = 0.1
+)
+
+// RecordScorer combines per-field Matcher scores into a single relevance
+// float for a record against a NameQuery.
+type RecordScorer struct {
+	matcher *Matcher
+}
+
+// NewRecordScorer builds a RecordScorer backed by matcher.
+func NewRecordScorer(matcher *Matcher) *RecordScorer {
+	return &RecordScorer{matcher: matcher}
+}
+
+// Score returns record's relevance to q, as a weighted average over the
+// fields q actually queries (fields left empty in q don't affect the
+// score). A record with none of its queried fields populated scores 0.
+func (s *RecordScorer) Score(record ElasticDataRecord, q NameQuery) float64 {
+	var firstName, lastName, suburb, street string
+	if record.Data.Name != nil {
+		firstName, lastName = record.Data.Name.First, record.Data.Name.Last
+	}
+	if record.Data.Address != nil {
+		suburb, street = record.Data.Address.Suburb, record.Data.Address.StreetName
+	}
+
+	var total, weightSum float64
+	add := func(weight float64, fq FieldQuery, candidate string) {
+		if fq.Value == "" {
+			return
+		}
+		mode := fq.Mode
+		if mode == "" {
+			mode = MatchExact
+		}
+		total += weight * s.matcher.Score(mode, fq.Value, candidate)
+		weightSum += weight
+	}
+
+	add(weightLastName, q.LastName, lastName)
+	add(weightFirstName, q.FirstName, firstName)
+	add(weightSuburb, q.Suburb, suburb)
+	add(weightStreet, q.Street, street)
+
+	if weightSum == 0 {
+		return 0
+	}
+	return total / weightSum
+}